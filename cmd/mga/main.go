@@ -2,11 +2,14 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"os"
 	"os/signal"
 	"sort"
+	"syscall"
 
+	"github.com/gomatic/modern-go-application/internal/app"
 	"github.com/gomatic/modern-go-application/internal/app/commands/resource"
 	"github.com/gomatic/modern-go-application/internal/app/commands/service"
 	"github.com/gomatic/modern-go-application/internal/app/log"
@@ -32,18 +35,27 @@ var (
 )
 
 func run() {
-	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, os.Kill)
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
 
 	c := appCreator(loggerCreator)
 
-	if err := c.RunContext(ctx, os.Args); err != nil {
+	if err := c.RunContext(ctx, app.FlagsFirst(c, os.Args)); err != nil {
 		slog.Error("Application error", "error", err)
 		cancel()
-		os.Exit(1)
+		os.Exit(exitCode(err))
 	}
 }
 
+// exitCode returns the exit code carried by err, or 1 if it has none.
+func exitCode(err error) int {
+	var coder cli.ExitCoder
+	if errors.As(err, &coder) {
+		return coder.ExitCode()
+	}
+	return 1
+}
+
 func createApp(getLogger log.GetLoggerFunc) *cli.App {
 	c := &cli.App{
 		Name:    appName,
@@ -53,6 +65,8 @@ func createApp(getLogger log.GetLoggerFunc) *cli.App {
 			resource.Command(appEnvPrefix),
 			service.Command(appEnvPrefix),
 		},
+		// Exit codes are derived from the returned error in run.
+		ExitErrHandler: func(*cli.Context, error) {},
 		Before: func(c *cli.Context) error {
 			c.App.Metadata[log.LoggerMetadataKey] = getLogger(c, loggerConfig)
 			return nil
@@ -6,6 +6,7 @@ import (
 	"context"
 	"encoding/json"
 	"log/slog"
+	"strings"
 
 	"github.com/gomatic/modern-go-application/internal/app/log"
 	"github.com/gomatic/modern-go-application/internal/slice"
@@ -33,10 +34,16 @@ type Runner[CONFIG Configurable, RESULT json.Marshaler] func(context.Context, *s
 
 var getLogger = log.GetLogger
 
-// Action is a generic action handler that executes a runner and outputs the result
+// Action is a generic action handler that validates the config, executes a runner and outputs the result
 func Action[C Configurable, R json.Marshaler](c *cli.Context, cfg C, runner Runner[C, R]) error {
 	logger := getLogger(c, cfg.LoggerConfig())
 
+	if v, ok := any(cfg).(Validator); ok {
+		if err := v.Validate(); err != nil {
+			return err
+		}
+	}
+
 	result, err := runner(c.Context, logger, cfg)
 	if err != nil {
 		return err
@@ -45,21 +52,25 @@ func Action[C Configurable, R json.Marshaler](c *cli.Context, cfg C, runner Runn
 	return Output(logger, cfg.OutputFilePath(), result)
 }
 
-func Default[C Configurable, R json.Marshaler](cfg C, runner Runner[C, R], options ...any) cli.ActionFunc {
+// Default returns a cli.ActionFunc that applies the options and runs the runner.
+// cfg is read when the action runs so values populated by flag destinations are seen.
+func Default[C Configurable, R json.Marshaler](cfg *C, runner Runner[C, R], options ...any) cli.ActionFunc {
 	return func(c *cli.Context) error {
 		for _, opt := range options {
 			switch o := opt.(type) {
-			case converter:
+			case Converter:
 				o.Convert(c)
 			default:
 				slog.Warn("Unknown option type", "type", o)
 			}
 		}
-		return Action(c, cfg, runner)
+		return Action(c, *cfg, runner)
 	}
 }
 
-type converter interface {
+// Converter is an option for Default that copies values from the command line
+// into the config before it runs.
+type Converter interface {
 	Convert(c *cli.Context)
 }
 
@@ -86,6 +97,17 @@ func StringSliceConverter[T ~string](flagName string, dest *[]T) sliceConverter[
 	}
 }
 
+// RepeatedConverter creates a converter for a cli.GenericFlag holding a Repeated value,
+// with the same conversion as StringSliceConverter.
+func RepeatedConverter[T ~string](flagName string, dest *[]T) sliceConverter[string, T] {
+	return sliceConverter[string, T]{
+		flagName: flagName,
+		slicer:   repeated,
+		dest:     dest,
+		convert:  slice.StringConverter[string, T],
+	}
+}
+
 // IntSliceConverter creates a converter for int slices with automatic type conversion.
 // The conversion defaults to casting: I(i), which works for all int-based custom types.
 func IntSliceConverter[T ~int](flagName string, dest *[]T) sliceConverter[int, T] {
@@ -97,7 +119,64 @@ func IntSliceConverter[T ~int](flagName string, dest *[]T) sliceConverter[int, T
 	}
 }
 
+// argConverter copies a positional argument into a config field.
+type argConverter[T ~string] struct {
+	index int // Position of the argument
+	dest  *T  // Pointer to destination field in config
+}
+
+func (a argConverter[T]) Convert(c *cli.Context) {
+	*a.dest = T(c.Args().Get(a.index))
+}
+
+// ArgConverter creates a converter that copies the positional argument at index into dest.
+// A missing argument yields the empty string.
+func ArgConverter[T ~string](index int, dest *T) argConverter[T] {
+	return argConverter[T]{index: index, dest: dest}
+}
+
+// joinedArgsConverter copies the positional arguments from an index on into a config field.
+type joinedArgsConverter[T ~string] struct {
+	from int // Position of the first argument
+	dest *T  // Pointer to destination field in config
+}
+
+func (j joinedArgsConverter[T]) Convert(c *cli.Context) {
+	args := c.Args().Slice()
+	*j.dest = T(strings.Join(args[min(j.from, len(args)):], " "))
+}
+
+// JoinedArgsConverter creates a converter that joins the positional arguments from index
+// on with spaces into dest, so that free text need not be quoted as a single argument.
+func JoinedArgsConverter[T ~string](from int, dest *T) joinedArgsConverter[T] {
+	return joinedArgsConverter[T]{from: from, dest: dest}
+}
+
+// isSetConverter records whether a flag was explicitly set.
+type isSetConverter struct {
+	flagName string // Name of the CLI flag
+	dest     *bool  // Pointer to destination field in config
+}
+
+func (i isSetConverter) Convert(c *cli.Context) {
+	*i.dest = c.IsSet(i.flagName)
+}
+
+// IsSetConverter creates a converter that records whether flagName was given on the
+// command line or through its environment variables. It lets a config distinguish an
+// explicit zero value from an omitted flag.
+func IsSetConverter(flagName string, dest *bool) isSetConverter {
+	return isSetConverter{flagName: flagName, dest: dest}
+}
+
 type slicerFunc[T any] func(*cli.Context, string) []T
 
 func intSlice(c *cli.Context, flagName string) []int       { return c.IntSlice(flagName) }
 func stringSlice(c *cli.Context, flagName string) []string { return c.StringSlice(flagName) }
+
+func repeated(c *cli.Context, flagName string) []string {
+	if r, ok := c.Generic(flagName).(*Repeated); ok && r != nil {
+		return *r
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package app
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+// FlagsFirst returns args with the flags of the command they invoke moved
+// ahead of its positional arguments. urfave/cli stops parsing flags at the
+// first positional argument, so without this "resource transition db --to
+// active" would leave --to unset. Everything after "--" stays positional.
+func FlagsFirst(app *cli.App, args []string) []string {
+	if len(args) == 0 {
+		return args
+	}
+	out := []string{args[0]}
+	rest := args[1:]
+
+	// Flags and names of parent commands are already where they belong.
+	flags, commands := app.Flags, app.Commands
+	for {
+		n := leadingFlags(flags, rest)
+		out, rest = append(out, rest[:n]...), rest[n:]
+		if len(rest) == 0 {
+			return out
+		}
+		i := slices.IndexFunc(commands, func(cmd *cli.Command) bool { return cmd.HasName(rest[0]) })
+		if i < 0 {
+			break
+		}
+		flags, commands = commands[i].Flags, commands[i].Subcommands
+		out, rest = append(out, rest[0]), rest[1:]
+	}
+
+	var positional []string
+	terminated := false
+	for len(rest) > 0 {
+		switch arg := rest[0]; {
+		case arg == "--":
+			positional = append(positional, rest[1:]...)
+			rest, terminated = nil, true
+		case isFlag(arg):
+			n := flagLen(flags, rest)
+			out, rest = append(out, rest[:n]...), rest[n:]
+		default:
+			positional = append(positional, arg)
+			rest = rest[1:]
+		}
+	}
+	if terminated {
+		out = append(out, "--")
+	}
+	return append(out, positional...)
+}
+
+// leadingFlags returns how many of args are flags, with their values, before
+// the first positional argument.
+func leadingFlags(flags []cli.Flag, args []string) int {
+	n := 0
+	for n < len(args) && isFlag(args[n]) && args[n] != "--" {
+		n += flagLen(flags, args[n:])
+	}
+	return min(n, len(args))
+}
+
+// flagLen returns how many arguments the flag at args[0] takes up: two when
+// it is a known flag taking a value given separately, otherwise one.
+func flagLen(flags []cli.Flag, args []string) int {
+	name, _, hasValue := strings.Cut(strings.TrimLeft(args[0], "-"), "=")
+	if hasValue || len(args) < 2 {
+		return 1
+	}
+	for _, f := range flags {
+		if !slices.Contains(f.Names(), name) {
+			continue
+		}
+		if v, ok := f.(cli.DocGenerationFlag); ok && v.TakesValue() {
+			return 2
+		}
+		return 1
+	}
+	return 1
+}
+
+func isFlag(arg string) bool {
+	return len(arg) > 1 && arg[0] == '-'
+}
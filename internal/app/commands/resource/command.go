@@ -4,7 +4,25 @@ package resource
 import (
 	"github.com/gomatic/modern-go-application/internal/app"
 	"github.com/gomatic/modern-go-application/internal/app/commands/resource/create"
+	"github.com/gomatic/modern-go-application/internal/app/commands/resource/export"
+	"github.com/gomatic/modern-go-application/internal/app/commands/resource/get"
+	"github.com/gomatic/modern-go-application/internal/app/commands/resource/graph"
+	"github.com/gomatic/modern-go-application/internal/app/commands/resource/history"
+	"github.com/gomatic/modern-go-application/internal/app/commands/resource/importer"
 	"github.com/gomatic/modern-go-application/internal/app/commands/resource/list"
+	"github.com/gomatic/modern-go-application/internal/app/commands/resource/namespace"
+	"github.com/gomatic/modern-go-application/internal/app/commands/resource/purge"
+	"github.com/gomatic/modern-go-application/internal/app/commands/resource/reap"
+	"github.com/gomatic/modern-go-application/internal/app/commands/resource/remove"
+	"github.com/gomatic/modern-go-application/internal/app/commands/resource/restore"
+	"github.com/gomatic/modern-go-application/internal/app/commands/resource/rollback"
+	"github.com/gomatic/modern-go-application/internal/app/commands/resource/schema"
+	"github.com/gomatic/modern-go-application/internal/app/commands/resource/search"
+	"github.com/gomatic/modern-go-application/internal/app/commands/resource/stats"
+	"github.com/gomatic/modern-go-application/internal/app/commands/resource/template"
+	"github.com/gomatic/modern-go-application/internal/app/commands/resource/transition"
+	"github.com/gomatic/modern-go-application/internal/app/commands/resource/update"
+	"github.com/gomatic/modern-go-application/internal/app/commands/resource/watch"
 	"github.com/urfave/cli/v2"
 )
 
@@ -15,14 +33,33 @@ const (
 	argsUsage   = "[command]"
 	description = `Manage application resources.
 
-This command provides subcommands for creating and listing resources.
+This command provides subcommands for creating, fetching, listing, searching,
+counting, updating, transitioning and deleting resources. Deleted resources
+go to a trash from which they can be restored until they are purged. Every change is kept as
+a revision that can be inspected and rolled back to. Resources can be
+exported and imported in bulk as NDJSON, JSON or CSV, and changes can be
+followed as they happen with watch. Resources of a kind carry a spec checked
+against the JSON Schema registered for that kind, and can be created from
+reusable templates. Resources can depend on
+each other; graph shows the dependencies, and delete keeps dependencies
+from disappearing under live dependents. Resources created with a TTL
+expire, and reap deletes them once they have.
+Resources live in namespaces, selected with --namespace or MGA_NAMESPACE
+(default: "default"); names are unique within a namespace.
+Resources are stored under --state-dir (default: $XDG_STATE_HOME/mga).
 
 Examples:
   # Create a new resource
   modern-go-application resource create --name my-resource --enabled
 
+  # Show a single resource
+  modern-go-application resource get my-resource
+
   # List resources
   modern-go-application resource list --limit 10
+
+  # Search resources
+  modern-go-application resource search payment api
 `
 )
 
@@ -35,7 +72,25 @@ func Command(prefix app.AppEnvPrefix) *cli.Command {
 		Description: description,
 		Subcommands: []*cli.Command{
 			create.Command(prefix),
+			get.Command(prefix),
 			list.Command(prefix),
+			search.Command(prefix),
+			stats.Command(prefix),
+			update.Command(prefix),
+			transition.Command(prefix),
+			remove.Command(prefix),
+			restore.Command(prefix),
+			purge.Command(prefix),
+			reap.Command(prefix),
+			history.Command(prefix),
+			rollback.Command(prefix),
+			export.Command(prefix),
+			importer.Command(prefix),
+			watch.Command(prefix),
+			schema.Command(prefix),
+			template.Command(prefix),
+			namespace.Command(prefix),
+			graph.Command(prefix),
 		},
 	}
 }
@@ -2,6 +2,8 @@
 package create
 
 import (
+	"time"
+
 	"github.com/gomatic/modern-go-application/internal/app"
 	"github.com/gomatic/modern-go-application/internal/resource/create"
 	"github.com/urfave/cli/v2"
@@ -17,7 +19,33 @@ const (
 This command demonstrates various configuration types:
   - Strings: name, description
   - Booleans: enabled, dry-run, force
-  - String slices: tags
+  - String slices: tags, labels
+
+Names must be DNS labels (lowercase letters, digits and '-', at most 63
+characters). Tags may contain letters, digits and - _ . : / (at most 63
+characters) and descriptions are limited to 1024 characters. Invalid input
+is reported all at once with exit code 2.
+
+A resource may have a kind and a spec of custom attributes. The spec is
+read from --spec-file and/or built with --set; values that parse as JSON keep
+their type, and each --set is taken whole, commas included, so lists and
+objects can be given as --set 'zones=["a","b"]'. The spec must match the
+JSON Schema registered for the kind (see "resource schema").
+
+Defaults can come from a template given with --template: a JSON file, or the
+name of a template in <state-dir>/templates (see "resource template list").
+A template may set the name, description, kind, tags, labels, enabled state
+and spec, and its strings may hold {{ .var }} placeholders filled in with
+--var name=value. Values given on the command line override the template's;
+labels are merged, and --spec-file replaces the template's spec.
+
+A resource given --ttl or --expires-at expires at that time; "resource reap"
+deletes expired resources.
+
+Creating a resource whose name is already taken fails with exit code 4
+unless --force is given, in which case the existing resource is replaced.
+The replacement keeps the status and its history; with --enabled it moves
+to active as "resource transition" would.
 
 Examples:
   # Create a simple resource
@@ -28,9 +56,26 @@ Examples:
     --name my-resource \
     --description "My test resource" \
     --tags prod,critical \
+    --label env=prod --label tier=db \
     --enabled \
     --dry-run
 
+  # Create a typed resource with a spec
+  modern-go-application resource create \
+    --name db-1 --kind database \
+    --spec-file db.json --set capacity=100 --set region=eu-west-1
+
+  # Create from a template, overriding its tags
+  modern-go-application resource create \
+    --template web-service.json --var name=checkout --var port=8080 \
+    --tags prod
+
+  # Create a short-lived test resource
+  modern-go-application resource create --name ci-run-42 --ttl 72h
+
+  # Declare dependencies on existing resources
+  modern-go-application resource create --name web --depends-on db-1,cache
+
   # Using environment variables
   MODERN_GO_APP_RESOURCE_CREATE_NAME=my-resource \
   MODERN_GO_APP_RESOURCE_CREATE_ENABLED=true \
@@ -40,9 +85,18 @@ Examples:
 
 // Flag names
 const (
+	flagTemplate    = "template"
+	flagVar         = "var"
 	flagName        = "name"
+	flagKind        = "kind"
 	flagDescription = "description"
 	flagTags        = "tags"
+	flagLabel       = "label"
+	flagSpecFile    = "spec-file"
+	flagSet         = "set"
+	flagDependsOn   = "depends-on"
+	flagTTL         = "ttl"
+	flagExpiresAt   = "expires-at"
 	flagEnabled     = "enabled"
 	flagDryRun      = "dry-run"
 	flagForce       = "force"
@@ -61,7 +115,15 @@ func Command(prefix app.AppEnvPrefix) *cli.Command {
 		ArgsUsage:   argsUsage,
 		Description: description,
 		Flags:       flags(prefix),
-		Action:      app.Default(cfg, runAction, app.StringSliceConverter(flagTags, &cfg.Tags)),
+		Action: app.Default(&cfg, runAction,
+			app.StringSliceConverter(flagVar, &cfg.Vars),
+			app.IsSetConverter(flagDescription, &cfg.SetDescription),
+			app.IsSetConverter(flagEnabled, &cfg.SetEnabled),
+			app.StringSliceConverter(flagTags, &cfg.Tags),
+			app.StringSliceConverter(flagLabel, &cfg.Labels),
+			app.RepeatedConverter(flagSet, &cfg.Spec),
+			app.StringSliceConverter(flagDependsOn, &cfg.DependsOn),
+		),
 	}
 }
 
@@ -70,6 +132,17 @@ func flags(prefix app.AppEnvPrefix) []cli.Flag {
 	envPrefix := string(prefix) + "RESOURCE_CREATE_"
 
 	baseFlags := []cli.Flag{
+		&cli.StringFlag{
+			Name:        flagTemplate,
+			Usage:       "Template file, or name of a template in <state-dir>/templates, providing default values",
+			EnvVars:     []string{envPrefix + "TEMPLATE"},
+			Destination: (*string)(&cfg.Template),
+		},
+		&cli.StringSliceFlag{
+			Name:    flagVar,
+			Usage:   "Template variable as name=value (can be specified multiple times)",
+			EnvVars: []string{envPrefix + "VARS"},
+		},
 		&cli.StringFlag{
 			Name:        flagName,
 			Aliases:     []string{"n"},
@@ -77,6 +150,13 @@ func flags(prefix app.AppEnvPrefix) []cli.Flag {
 			EnvVars:     []string{envPrefix + "NAME"},
 			Destination: (*string)(&cfg.Name), // Safe: Name is string underneath
 		},
+		&cli.StringFlag{
+			Name:        flagKind,
+			Aliases:     []string{"k"},
+			Usage:       "Resource kind; the spec must match the schema registered for it",
+			EnvVars:     []string{envPrefix + "KIND"},
+			Destination: (*string)(&cfg.Kind),
+		},
 		&cli.StringFlag{
 			Name:        flagDescription,
 			Aliases:     []string{"desc"},
@@ -91,6 +171,41 @@ func flags(prefix app.AppEnvPrefix) []cli.Flag {
 			Usage:   "Resource tags (can be specified multiple times or comma-separated)",
 			EnvVars: []string{envPrefix + "TAGS"},
 		},
+		&cli.StringSliceFlag{
+			Name:    flagLabel,
+			Aliases: []string{"l"},
+			Usage:   "Resource label as key=value (can be specified multiple times)",
+			EnvVars: []string{envPrefix + "LABELS"},
+		},
+		&cli.StringFlag{
+			Name:        flagSpecFile,
+			Usage:       "JSON file holding the resource spec",
+			EnvVars:     []string{envPrefix + "SPEC_FILE"},
+			Destination: (*string)(&cfg.SpecFile),
+		},
+		&cli.GenericFlag{
+			Name:    flagSet,
+			Usage:   "Spec value as path=value, e.g. limits.cpu=2 (can be specified multiple times; not split on commas; applied over --spec-file)",
+			EnvVars: []string{envPrefix + "SPEC"},
+			Value:   &app.Repeated{},
+		},
+		&cli.StringSliceFlag{
+			Name:    flagDependsOn,
+			Usage:   "ID or name of a resource this one depends on (can be specified multiple times or comma-separated)",
+			EnvVars: []string{envPrefix + "DEPENDS_ON"},
+		},
+		&cli.DurationFlag{
+			Name:        flagTTL,
+			Usage:       "Time until the resource expires, e.g. 72h",
+			EnvVars:     []string{envPrefix + "TTL"},
+			Destination: (*time.Duration)(&cfg.TTL), // Safe: TTL is time.Duration underneath
+		},
+		&cli.StringFlag{
+			Name:        flagExpiresAt,
+			Usage:       "Time the resource expires (RFC 3339 or 2006-01-02); instead of --ttl",
+			EnvVars:     []string{envPrefix + "EXPIRES_AT"},
+			Destination: (*string)(&cfg.ExpiresAt),
+		},
 		&cli.BoolFlag{
 			Name:        flagEnabled,
 			Aliases:     []string{"e"},
@@ -109,12 +224,16 @@ func flags(prefix app.AppEnvPrefix) []cli.Flag {
 		&cli.BoolFlag{
 			Name:        flagForce,
 			Aliases:     []string{"f"},
-			Usage:       "Force creation even if resource exists",
+			Usage:       "Replace an existing resource with the same name",
 			EnvVars:     []string{envPrefix + "FORCE"},
 			Value:       false,
 			Destination: &cfg.Force,
 		},
 	}
 
+	baseFlags = app.WithActorFlags(prefix, (*string)(&cfg.Actor), baseFlags)
+	baseFlags = app.WithNamespaceFlags(prefix, (*string)(&cfg.Namespace), baseFlags)
+	baseFlags = app.WithStateFlags(prefix, (*string)(&cfg.State), baseFlags)
+
 	return app.WithOutputFlags(prefix, &cfg.Output, baseFlags)
 }
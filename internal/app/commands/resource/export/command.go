@@ -0,0 +1,74 @@
+// Package export implements the resource export command
+package export
+
+import (
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/resource"
+	"github.com/gomatic/modern-go-application/internal/resource/export"
+	"github.com/urfave/cli/v2"
+)
+
+// Command metadata
+const (
+	Name        = "export"
+	usage       = "Export resources in bulk"
+	argsUsage   = "[options]"
+	description = `Write every resource that is not in the trash as NDJSON, a JSON array or CSV.
+The output can be read back with "resource import".
+
+In CSV, tags and labels are comma-separated within their cells.
+
+Examples:
+  # Back up all resources
+  modern-go-application resource export --output resources.ndjson
+
+  # Export a spreadsheet
+  modern-go-application resource export --format csv --output resources.csv
+
+  # Copy resources between state directories
+  modern-go-application resource export --state-dir ./old |
+    modern-go-application resource import --state-dir ./new --file -
+`
+)
+
+// Flag names
+const (
+	flagFormat = "format"
+)
+
+// Package-level config populated by urfave/cli via Destination
+var cfg export.Config
+
+var runAction = export.Run
+
+// Command returns the CLI command for exporting resources
+func Command(prefix app.AppEnvPrefix) *cli.Command {
+	return &cli.Command{
+		Name:        Name,
+		Usage:       usage,
+		ArgsUsage:   argsUsage,
+		Description: description,
+		Flags:       flags(prefix),
+		Action:      app.Default(&cfg, runAction),
+	}
+}
+
+// flags defines all command flags
+func flags(prefix app.AppEnvPrefix) []cli.Flag {
+	envPrefix := string(prefix) + "RESOURCE_EXPORT_"
+
+	baseFlags := []cli.Flag{
+		&cli.StringFlag{
+			Name:        flagFormat,
+			Usage:       "Output format (ndjson, json, csv)",
+			EnvVars:     []string{envPrefix + "FORMAT"},
+			Value:       string(resource.FormatNDJSON),
+			Destination: (*string)(&cfg.Format),
+		},
+	}
+
+	baseFlags = app.WithNamespaceFlags(prefix, (*string)(&cfg.Namespace), baseFlags)
+	baseFlags = app.WithStateFlags(prefix, (*string)(&cfg.State), baseFlags)
+
+	return app.WithOutputFlags(prefix, &cfg.Output, baseFlags)
+}
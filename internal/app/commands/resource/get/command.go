@@ -0,0 +1,52 @@
+// Package get implements the resource get command
+package get
+
+import (
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/resource/get"
+	"github.com/urfave/cli/v2"
+)
+
+// Command metadata
+const (
+	Name        = "get"
+	usage       = "Show a single resource"
+	argsUsage   = "[options] <id|name>"
+	description = `Show the full record of a resource identified by ID or name.
+
+IDs take precedence over names. When nothing matches, the command fails
+with exit code 3.
+
+Examples:
+  # Get a resource by ID
+  modern-go-application resource get res-01jh5v6k2m8q9r3s4t5v6w7x8y
+
+  # Get a resource by name
+  modern-go-application resource get my-resource
+`
+)
+
+// Package-level config populated by urfave/cli via Destination
+var cfg get.Config
+
+var runAction = get.Run
+
+// Command returns the CLI command for getting a resource
+func Command(prefix app.AppEnvPrefix) *cli.Command {
+	return &cli.Command{
+		Name:        Name,
+		Usage:       usage,
+		ArgsUsage:   argsUsage,
+		Description: description,
+		Flags:       flags(prefix),
+		Action:      app.Default(&cfg, runAction, app.ArgConverter(0, &cfg.Ref)),
+	}
+}
+
+// flags defines all command flags
+func flags(prefix app.AppEnvPrefix) []cli.Flag {
+	baseFlags := app.WithNamespaceFlags(prefix, (*string)(&cfg.Namespace), nil)
+	baseFlags = app.WithStateFlags(prefix, (*string)(&cfg.State), baseFlags)
+
+	return app.WithOutputFlags(prefix, &cfg.Output, baseFlags)
+}
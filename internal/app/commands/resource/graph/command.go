@@ -0,0 +1,71 @@
+// Package graph implements the resource graph command
+package graph
+
+import (
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/resource/graph"
+	"github.com/urfave/cli/v2"
+)
+
+// Command metadata
+const (
+	Name        = "graph"
+	usage       = "Show the dependency graph of a resource"
+	argsUsage   = "[options] <id|name>"
+	description = `Show a resource together with every resource it depends on and every
+resource depending on it, directly or not.
+
+Edges run from a resource to the resources it depends on. The DOT output
+can be rendered with Graphviz; the named resource is drawn bold and trashed
+resources dashed. Options must precede the ID or name.
+
+Examples:
+  # Show the graph as JSON
+  modern-go-application resource graph web
+
+  # Render the graph with Graphviz
+  modern-go-application resource graph --format dot web | dot -Tsvg > web.svg
+`
+)
+
+// Flag names
+const (
+	flagFormat = "format"
+)
+
+// Package-level config populated by urfave/cli via Destination
+var cfg graph.Config
+
+var runAction = graph.Run
+
+// Command returns the CLI command for showing resource dependency graphs
+func Command(prefix app.AppEnvPrefix) *cli.Command {
+	return &cli.Command{
+		Name:        Name,
+		Usage:       usage,
+		ArgsUsage:   argsUsage,
+		Description: description,
+		Flags:       flags(prefix),
+		Action:      app.Default(&cfg, runAction, app.ArgConverter(0, &cfg.Ref)),
+	}
+}
+
+// flags defines all command flags
+func flags(prefix app.AppEnvPrefix) []cli.Flag {
+	envPrefix := string(prefix) + "RESOURCE_GRAPH_"
+
+	baseFlags := []cli.Flag{
+		&cli.StringFlag{
+			Name:        flagFormat,
+			Usage:       "Output format (json, dot)",
+			EnvVars:     []string{envPrefix + "FORMAT"},
+			Value:       string(graph.FormatJSON),
+			Destination: (*string)(&cfg.Format),
+		},
+	}
+
+	baseFlags = app.WithNamespaceFlags(prefix, (*string)(&cfg.Namespace), baseFlags)
+	baseFlags = app.WithStateFlags(prefix, (*string)(&cfg.State), baseFlags)
+
+	return app.WithOutputFlags(prefix, &cfg.Output, baseFlags)
+}
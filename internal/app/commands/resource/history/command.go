@@ -0,0 +1,49 @@
+// Package history implements the resource history command
+package history
+
+import (
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/resource/history"
+	"github.com/urfave/cli/v2"
+)
+
+// Command metadata
+const (
+	Name        = "history"
+	usage       = "List the revisions of a resource"
+	argsUsage   = "[options] <id|name>"
+	description = `List every revision of a resource, oldest first.
+
+Each revision holds the operation, its time, a field-level diff from the
+previous revision and a full snapshot of the resource.
+
+Examples:
+  # Show the history of a resource
+  modern-go-application resource history my-resource
+`
+)
+
+// Package-level config populated by urfave/cli via Destination
+var cfg history.Config
+
+var runAction = history.Run
+
+// Command returns the CLI command for listing resource revisions
+func Command(prefix app.AppEnvPrefix) *cli.Command {
+	return &cli.Command{
+		Name:        Name,
+		Usage:       usage,
+		ArgsUsage:   argsUsage,
+		Description: description,
+		Flags:       flags(prefix),
+		Action:      app.Default(&cfg, runAction, app.ArgConverter(0, &cfg.Ref)),
+	}
+}
+
+// flags defines all command flags
+func flags(prefix app.AppEnvPrefix) []cli.Flag {
+	baseFlags := app.WithNamespaceFlags(prefix, (*string)(&cfg.Namespace), nil)
+	baseFlags = app.WithStateFlags(prefix, (*string)(&cfg.State), baseFlags)
+
+	return app.WithOutputFlags(prefix, &cfg.Output, baseFlags)
+}
@@ -15,9 +15,11 @@ const (
 	description = `List resources with optional filtering and pagination.
 
 This command demonstrates various configuration types:
-  - Strings: include/exclude patterns, sort field
+  - Strings: include/exclude patterns, label selector, filter expression, sort field
+  - Times: absolute (RFC 3339 or 2006-01-02) or relative (24h, 7d) bounds
   - Integers: limit, offset
-  - Booleans: ascending sort
+  - Opaque tokens: cursor
+  - Booleans: ascending sort, include deleted, all namespaces
   - String slices: filter statuses
 
 Examples:
@@ -31,6 +33,24 @@ Examples:
     --status active,pending \
     --limit 10
 
+  # Filter by labels
+  modern-go-application resource list --selector 'env=prod,tier!=cache,team in (a,b)'
+
+  # Filter with an expression over id, namespace, version, name, kind,
+  # status, tags, labels, created_at, updated_at, expires_at and deleted_at
+  modern-go-application resource list \
+    --where 'status == "active" && "prod" in tags && created_at > "2025-01-01"'
+
+  # Resources created in January that changed in the last day
+  modern-go-application resource list \
+    --created-after 2025-01-01 --created-before 2025-02-01 --updated-since 24h
+
+  # List resources of every namespace
+  modern-go-application resource list --all-namespaces
+
+  # Filter names with a regular expression
+  modern-go-application resource list --include 're:^web-[0-9]+$'
+
   # List with pagination and sorting
   modern-go-application resource list \
     --limit 20 \
@@ -38,6 +58,12 @@ Examples:
     --sort-by name \
     --ascending
 
+  # Walk every page; pass each next_cursor back until it is absent
+  modern-go-application resource list --limit 100 --cursor "$NEXT_CURSOR"
+
+  # Sort by several fields, newest first within each status
+  modern-go-application resource list --sort-by status,-created_at
+
   # Using environment variables
   MODERN_GO_APP_RESOURCE_LIST_LIMIT=10 \
   modern-go-application resource list
@@ -46,13 +72,21 @@ Examples:
 
 // Flag names
 const (
-	flagInclude   = "include"
-	flagExclude   = "exclude"
-	flagStatus    = "status"
-	flagLimit     = "limit"
-	flagOffset    = "offset"
-	flagSortBy    = "sort-by"
-	flagAscending = "ascending"
+	flagInclude       = "include"
+	flagExclude       = "exclude"
+	flagStatus        = "status"
+	flagDeleted       = "include-deleted"
+	flagAllNamespaces = "all-namespaces"
+	flagSelector      = "selector"
+	flagWhere         = "where"
+	flagCreatedAfter  = "created-after"
+	flagCreatedBefore = "created-before"
+	flagUpdatedSince  = "updated-since"
+	flagLimit         = "limit"
+	flagOffset        = "offset"
+	flagCursor        = "cursor"
+	flagSortBy        = "sort-by"
+	flagAscending     = "ascending"
 )
 
 // Package-level config populated by urfave/cli via Destination
@@ -68,7 +102,7 @@ func Command(prefix app.AppEnvPrefix) *cli.Command {
 		ArgsUsage:   argsUsage,
 		Description: description,
 		Flags:       flags(prefix),
-		Action:      app.Default(cfg, runAction, app.StringSliceConverter(flagStatus, &cfg.Statuses)),
+		Action:      app.Default(&cfg, runAction, FilterConverter(&cfg.Filter)),
 	}
 }
 
@@ -76,27 +110,7 @@ func Command(prefix app.AppEnvPrefix) *cli.Command {
 func flags(prefix app.AppEnvPrefix) []cli.Flag {
 	envPrefix := string(prefix) + "RESOURCE_LIST_"
 
-	baseFlags := []cli.Flag{
-		&cli.StringFlag{
-			Name:        flagInclude,
-			Aliases:     []string{"i"},
-			Usage:       "Include resources matching pattern",
-			EnvVars:     []string{envPrefix + "INCLUDE"},
-			Destination: (*string)(&cfg.IncludePatterns),
-		},
-		&cli.StringFlag{
-			Name:        flagExclude,
-			Aliases:     []string{"x"},
-			Usage:       "Exclude resources matching pattern",
-			EnvVars:     []string{envPrefix + "EXCLUDE"},
-			Destination: (*string)(&cfg.ExcludePatterns),
-		},
-		&cli.StringSliceFlag{
-			Name:    flagStatus,
-			Aliases: []string{"s"},
-			Usage:   "Filter by status (can be specified multiple times or comma-separated)",
-			EnvVars: []string{envPrefix + "STATUSES"},
-		},
+	baseFlags := append(FilterFlags(envPrefix, &cfg.Filter), []cli.Flag{
 		&cli.IntFlag{
 			Name:        flagLimit,
 			Aliases:     []string{"l"},
@@ -112,9 +126,16 @@ func flags(prefix app.AppEnvPrefix) []cli.Flag {
 			Value:       0,
 			Destination: (*int)(&cfg.Offset),
 		},
+		&cli.StringFlag{
+			Name:        flagCursor,
+			Aliases:     []string{"c"},
+			Usage:       "Continue after the next_cursor of a previous page (stable under concurrent inserts)",
+			EnvVars:     []string{envPrefix + "CURSOR"},
+			Destination: (*string)(&cfg.Cursor),
+		},
 		&cli.StringFlag{
 			Name:        flagSortBy,
-			Usage:       "Field to sort by",
+			Usage:       "Comma-separated fields to sort by (id, name, status, created_at, tag_count); prefix with - for descending",
 			EnvVars:     []string{envPrefix + "SORT_BY"},
 			Value:       "name",
 			Destination: (*string)(&cfg.SortBy),
@@ -122,12 +143,93 @@ func flags(prefix app.AppEnvPrefix) []cli.Flag {
 		&cli.BoolFlag{
 			Name:        flagAscending,
 			Aliases:     []string{"asc"},
-			Usage:       "Sort in ascending order",
+			Usage:       "Sort unprefixed --sort-by fields in ascending order",
 			EnvVars:     []string{envPrefix + "ASCENDING"},
 			Value:       true,
 			Destination: &cfg.Ascending,
 		},
-	}
+	}...)
+
+	baseFlags = app.WithNamespaceFlags(prefix, (*string)(&cfg.Namespace), baseFlags)
+	baseFlags = app.WithStateFlags(prefix, (*string)(&cfg.State), baseFlags)
 
 	return app.WithOutputFlags(prefix, &cfg.Output, baseFlags)
 }
+
+// FilterFlags defines the flags that fill a list.Filter, for commands that
+// select resources the way list does. Pair them with FilterConverter.
+func FilterFlags(envPrefix string, f *list.Filter) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:        flagInclude,
+			Aliases:     []string{"i"},
+			Usage:       "Include resources whose name matches (comma-separated globs, or re:<regexp>)",
+			EnvVars:     []string{envPrefix + "INCLUDE"},
+			Destination: (*string)(&f.IncludePatterns),
+		},
+		&cli.StringFlag{
+			Name:        flagExclude,
+			Aliases:     []string{"x"},
+			Usage:       "Exclude resources whose name matches; takes precedence over --include",
+			EnvVars:     []string{envPrefix + "EXCLUDE"},
+			Destination: (*string)(&f.ExcludePatterns),
+		},
+		&cli.StringSliceFlag{
+			Name:    flagStatus,
+			Aliases: []string{"s"},
+			Usage:   "Filter by status (can be specified multiple times or comma-separated)",
+			EnvVars: []string{envPrefix + "STATUSES"},
+		},
+		&cli.StringFlag{
+			Name:        flagSelector,
+			Usage:       "Label selector, e.g. 'env=prod,tier!=cache,team in (a,b)'",
+			EnvVars:     []string{envPrefix + "SELECTOR"},
+			Destination: (*string)(&f.Selector),
+		},
+		&cli.StringFlag{
+			Name:        flagWhere,
+			Aliases:     []string{"w"},
+			Usage:       `Filter expression, e.g. 'status == "active" && "prod" in tags' (operators: == != < <= > >= in && || !)`,
+			EnvVars:     []string{envPrefix + "WHERE"},
+			Destination: (*string)(&f.Where),
+		},
+		&cli.StringFlag{
+			Name:        flagCreatedAfter,
+			Usage:       "Only resources created after this time (RFC 3339, 2006-01-02, or a duration ago such as 24h or 7d)",
+			EnvVars:     []string{envPrefix + "CREATED_AFTER"},
+			Destination: (*string)(&f.CreatedAfter),
+		},
+		&cli.StringFlag{
+			Name:        flagCreatedBefore,
+			Usage:       "Only resources created before this time (RFC 3339, 2006-01-02, or a duration ago such as 24h or 7d)",
+			EnvVars:     []string{envPrefix + "CREATED_BEFORE"},
+			Destination: (*string)(&f.CreatedBefore),
+		},
+		&cli.StringFlag{
+			Name:        flagUpdatedSince,
+			Usage:       "Only resources changed at or after this time (RFC 3339, 2006-01-02, or a duration ago such as 24h or 7d)",
+			EnvVars:     []string{envPrefix + "UPDATED_SINCE"},
+			Destination: (*string)(&f.UpdatedSince),
+		},
+		&cli.BoolFlag{
+			Name:        flagDeleted,
+			Usage:       "Include resources in the trash",
+			EnvVars:     []string{envPrefix + "INCLUDE_DELETED"},
+			Value:       false,
+			Destination: &f.IncludeDeleted,
+		},
+		&cli.BoolFlag{
+			Name:        flagAllNamespaces,
+			Aliases:     []string{"A"},
+			Usage:       "Select resources of every namespace instead of --namespace",
+			EnvVars:     []string{envPrefix + "ALL_NAMESPACES"},
+			Value:       false,
+			Destination: &f.AllNamespaces,
+		},
+	}
+}
+
+// FilterConverter copies the --status flag defined by FilterFlags into f.
+func FilterConverter(f *list.Filter) app.Converter {
+	return app.StringSliceConverter(flagStatus, &f.Statuses)
+}
@@ -0,0 +1,53 @@
+// Package namespace provides the resource namespace CLI command.
+package namespace
+
+import (
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/app/commands/resource/namespace/create"
+	"github.com/gomatic/modern-go-application/internal/app/commands/resource/namespace/list"
+	"github.com/gomatic/modern-go-application/internal/app/commands/resource/namespace/remove"
+	"github.com/urfave/cli/v2"
+)
+
+// Command metadata
+const (
+	Name        = "namespace"
+	usage       = "Manage resource namespaces"
+	argsUsage   = "[command]"
+	description = `Manage the namespaces that scope resources.
+
+Every resource belongs to one namespace, chosen with --namespace or
+MGA_NAMESPACE on each resource command (default: "default"). Names are
+unique within a namespace, and resources are looked up by ID or name within
+it. The default namespace always exists; others must be created before use
+and can only be deleted once they hold no resources, not even in the trash.
+Schemas and templates are shared by all namespaces.
+
+Examples:
+  # Create a namespace and a resource in it
+  modern-go-application resource namespace create team-a
+  modern-go-application resource create --namespace team-a --name web
+
+  # Show every namespace with its resource counts
+  modern-go-application resource namespace list
+
+  # Delete an empty namespace
+  modern-go-application resource namespace delete team-a
+`
+)
+
+// Command returns the CLI command for namespace management (parent command)
+func Command(prefix app.AppEnvPrefix) *cli.Command {
+	return &cli.Command{
+		Name:        Name,
+		Aliases:     []string{"ns"},
+		Usage:       usage,
+		ArgsUsage:   argsUsage,
+		Description: description,
+		Subcommands: []*cli.Command{
+			create.Command(prefix),
+			list.Command(prefix),
+			remove.Command(prefix),
+		},
+	}
+}
@@ -0,0 +1,46 @@
+// Package create implements the resource namespace create command
+package create
+
+import (
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/resource/namespace/create"
+	"github.com/urfave/cli/v2"
+)
+
+// Command metadata
+const (
+	Name        = "create"
+	usage       = "Create a namespace"
+	argsUsage   = "[options] <namespace>"
+	description = `Create a namespace. Namespace names follow the rules for resource names.
+Creating a namespace that exists fails with exit code 4.
+
+Examples:
+  # Create a namespace for a team
+  modern-go-application resource namespace create team-a
+`
+)
+
+// Package-level config populated by urfave/cli via Destination
+var cfg create.Config
+
+var runAction = create.Run
+
+// Command returns the CLI command for creating namespaces
+func Command(prefix app.AppEnvPrefix) *cli.Command {
+	return &cli.Command{
+		Name:        Name,
+		Usage:       usage,
+		ArgsUsage:   argsUsage,
+		Description: description,
+		Flags:       flags(prefix),
+		Action:      app.Default(&cfg, runAction, app.ArgConverter(0, &cfg.Name)),
+	}
+}
+
+// flags defines all command flags
+func flags(prefix app.AppEnvPrefix) []cli.Flag {
+	baseFlags := app.WithStateFlags(prefix, (*string)(&cfg.State), nil)
+
+	return app.WithOutputFlags(prefix, &cfg.Output, baseFlags)
+}
@@ -0,0 +1,46 @@
+// Package list implements the resource namespace list command
+package list
+
+import (
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/resource/namespace/list"
+	"github.com/urfave/cli/v2"
+)
+
+// Command metadata
+const (
+	Name        = "list"
+	usage       = "List namespaces"
+	argsUsage   = "[options]"
+	description = `List every namespace with the number of resources it holds, live and in
+the trash.
+
+Examples:
+  # Show every namespace
+  modern-go-application resource namespace list
+`
+)
+
+// Package-level config populated by urfave/cli via Destination
+var cfg list.Config
+
+var runAction = list.Run
+
+// Command returns the CLI command for listing namespaces
+func Command(prefix app.AppEnvPrefix) *cli.Command {
+	return &cli.Command{
+		Name:        Name,
+		Usage:       usage,
+		ArgsUsage:   argsUsage,
+		Description: description,
+		Flags:       flags(prefix),
+		Action:      app.Default(&cfg, runAction),
+	}
+}
+
+// flags defines all command flags
+func flags(prefix app.AppEnvPrefix) []cli.Flag {
+	baseFlags := app.WithStateFlags(prefix, (*string)(&cfg.State), nil)
+
+	return app.WithOutputFlags(prefix, &cfg.Output, baseFlags)
+}
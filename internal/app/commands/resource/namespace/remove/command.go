@@ -0,0 +1,49 @@
+// Package remove implements the resource namespace delete command
+package remove
+
+import (
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/resource/namespace/remove"
+	"github.com/urfave/cli/v2"
+)
+
+// Command metadata
+const (
+	Name        = "delete"
+	usage       = "Delete an empty namespace"
+	argsUsage   = "[options] <namespace>"
+	description = `Delete a namespace. A namespace that still holds resources, including
+resources in the trash, is not deleted and the command fails with exit code
+7; delete and purge its resources first. The default namespace cannot be
+deleted.
+
+Examples:
+  # Delete a namespace
+  modern-go-application resource namespace delete team-a
+`
+)
+
+// Package-level config populated by urfave/cli via Destination
+var cfg remove.Config
+
+var runAction = remove.Run
+
+// Command returns the CLI command for deleting namespaces
+func Command(prefix app.AppEnvPrefix) *cli.Command {
+	return &cli.Command{
+		Name:        Name,
+		Aliases:     []string{"rm"},
+		Usage:       usage,
+		ArgsUsage:   argsUsage,
+		Description: description,
+		Flags:       flags(prefix),
+		Action:      app.Default(&cfg, runAction, app.ArgConverter(0, &cfg.Name)),
+	}
+}
+
+// flags defines all command flags
+func flags(prefix app.AppEnvPrefix) []cli.Flag {
+	baseFlags := app.WithStateFlags(prefix, (*string)(&cfg.State), nil)
+
+	return app.WithOutputFlags(prefix, &cfg.Output, baseFlags)
+}
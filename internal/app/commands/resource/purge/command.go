@@ -0,0 +1,77 @@
+// Package purge implements the resource purge command
+package purge
+
+import (
+	"time"
+
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/resource/purge"
+	"github.com/urfave/cli/v2"
+)
+
+// Command metadata
+const (
+	Name        = "purge"
+	usage       = "Permanently remove deleted resources"
+	argsUsage   = "[options]"
+	description = `Permanently remove resources that have been in the trash for a while.
+A resource is kept while a resource that is not purged depends on it.
+
+Examples:
+  # Remove everything deleted more than 30 days ago
+  modern-go-application resource purge --older-than 720h
+
+  # Empty the whole trash, previewing first
+  modern-go-application resource purge --older-than 0 --dry-run
+`
+)
+
+// Flag names
+const (
+	flagOlderThan = "older-than"
+	flagDryRun    = "dry-run"
+)
+
+// Package-level config populated by urfave/cli via Destination
+var cfg purge.Config
+
+var runAction = purge.Run
+
+// Command returns the CLI command for purging the trash
+func Command(prefix app.AppEnvPrefix) *cli.Command {
+	return &cli.Command{
+		Name:        Name,
+		Usage:       usage,
+		ArgsUsage:   argsUsage,
+		Description: description,
+		Flags:       flags(prefix),
+		Action:      app.Default(&cfg, runAction),
+	}
+}
+
+// flags defines all command flags
+func flags(prefix app.AppEnvPrefix) []cli.Flag {
+	envPrefix := string(prefix) + "RESOURCE_PURGE_"
+
+	baseFlags := []cli.Flag{
+		&cli.DurationFlag{
+			Name:        flagOlderThan,
+			Usage:       "Only purge resources deleted at least this long ago",
+			EnvVars:     []string{envPrefix + "OLDER_THAN"},
+			Value:       720 * time.Hour,
+			Destination: (*time.Duration)(&cfg.OlderThan), // Safe: Age is time.Duration underneath
+		},
+		&cli.BoolFlag{
+			Name:        flagDryRun,
+			Usage:       "Report what would be purged without removing anything",
+			EnvVars:     []string{envPrefix + "DRY_RUN"},
+			Value:       false,
+			Destination: &cfg.DryRun,
+		},
+	}
+
+	baseFlags = app.WithNamespaceFlags(prefix, (*string)(&cfg.Namespace), baseFlags)
+	baseFlags = app.WithStateFlags(prefix, (*string)(&cfg.State), baseFlags)
+
+	return app.WithOutputFlags(prefix, &cfg.Output, baseFlags)
+}
@@ -0,0 +1,99 @@
+// Package reap implements the resource reap command
+package reap
+
+import (
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/resource/reap"
+	"github.com/urfave/cli/v2"
+)
+
+// Command metadata
+const (
+	Name        = "reap"
+	usage       = "Delete expired resources"
+	argsUsage   = "[options]"
+	description = `Delete the resources whose expiry, set with create --ttl or --expires-at,
+has passed. Expired resources go to the trash unless --force is given.
+An expired resource is kept while resources that have not expired depend on it.
+
+Each pass is written as one line of NDJSON. Without --interval a single pass
+is made, suitable for cron; with it, reap keeps running until interrupted
+and writes only the passes that reaped something. --dry-run makes a single
+pass and cannot be combined with --interval.
+
+Examples:
+  # Preview what would be reaped
+  modern-go-application resource reap --dry-run
+
+  # Reap every namespace once a minute
+  modern-go-application resource reap -A --interval 1m
+`
+)
+
+// Flag names
+const (
+	flagDryRun        = "dry-run"
+	flagForce         = "force"
+	flagInterval      = "interval"
+	flagAllNamespaces = "all-namespaces"
+)
+
+// Package-level config populated by urfave/cli via Destination
+var cfg reap.Config
+
+var runAction = reap.Run
+
+// Command returns the CLI command for reaping expired resources
+func Command(prefix app.AppEnvPrefix) *cli.Command {
+	return &cli.Command{
+		Name:        Name,
+		Usage:       usage,
+		ArgsUsage:   argsUsage,
+		Description: description,
+		Flags:       flags(prefix),
+		Action:      app.Default(&cfg, runAction),
+	}
+}
+
+// flags defines all command flags
+func flags(prefix app.AppEnvPrefix) []cli.Flag {
+	envPrefix := string(prefix) + "RESOURCE_REAP_"
+
+	baseFlags := []cli.Flag{
+		&cli.BoolFlag{
+			Name:        flagDryRun,
+			Usage:       "Report what would be reaped without changing anything",
+			EnvVars:     []string{envPrefix + "DRY_RUN"},
+			Value:       false,
+			Destination: &cfg.DryRun,
+		},
+		&cli.BoolFlag{
+			Name:        flagForce,
+			Aliases:     []string{"f"},
+			Usage:       "Delete expired resources permanently instead of moving them to the trash",
+			EnvVars:     []string{envPrefix + "FORCE"},
+			Value:       false,
+			Destination: &cfg.Force,
+		},
+		&cli.DurationFlag{
+			Name:        flagInterval,
+			Usage:       "Keep running, reaping every interval (0 = single pass)",
+			EnvVars:     []string{envPrefix + "INTERVAL"},
+			Value:       0,
+			Destination: &cfg.Interval,
+		},
+		&cli.BoolFlag{
+			Name:        flagAllNamespaces,
+			Aliases:     []string{"A"},
+			Usage:       "Reap resources of every namespace instead of --namespace",
+			EnvVars:     []string{envPrefix + "ALL_NAMESPACES"},
+			Value:       false,
+			Destination: &cfg.AllNamespaces,
+		},
+	}
+
+	baseFlags = app.WithNamespaceFlags(prefix, (*string)(&cfg.Namespace), baseFlags)
+	baseFlags = app.WithStateFlags(prefix, (*string)(&cfg.State), baseFlags)
+
+	return app.WithOutputFlags(prefix, &cfg.Output, baseFlags)
+}
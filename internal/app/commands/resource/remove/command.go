@@ -0,0 +1,105 @@
+// Package remove implements the resource delete command
+package remove
+
+import (
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/resource/remove"
+	"github.com/urfave/cli/v2"
+)
+
+// Command metadata
+const (
+	Name        = "delete"
+	usage       = "Delete a resource"
+	argsUsage   = "[options] <id|name>"
+	description = `Move a resource to the trash.
+
+Trashed resources are hidden from "resource list" (unless --include-deleted
+is given) and can be brought back with "resource restore". They are removed
+for good by "resource purge", or immediately with --force.
+
+A resource that live resources depend on is not deleted (exit code 6) unless
+--cascade is given, which deletes its dependents first. With --force, resources
+in the trash count as dependents too, as they could not be restored without it.
+
+Examples:
+  # Move a resource to the trash
+  modern-go-application resource delete my-resource
+
+  # Preview a permanent deletion
+  modern-go-application resource delete --force --dry-run res-01jh5v6k2m8q9r3s4t5v6w7x8y
+
+  # Delete a resource together with everything that depends on it
+  modern-go-application resource delete --cascade db-1
+
+  # Only delete the version that was reviewed
+  modern-go-application resource delete --if-version 4 my-resource
+`
+)
+
+// Flag names
+const (
+	flagDryRun    = "dry-run"
+	flagForce     = "force"
+	flagCascade   = "cascade"
+	flagIfVersion = "if-version"
+)
+
+// Package-level config populated by urfave/cli via Destination
+var cfg remove.Config
+
+var runAction = remove.Run
+
+// Command returns the CLI command for deleting resources
+func Command(prefix app.AppEnvPrefix) *cli.Command {
+	return &cli.Command{
+		Name:        Name,
+		Aliases:     []string{"rm"},
+		Usage:       usage,
+		ArgsUsage:   argsUsage,
+		Description: description,
+		Flags:       flags(prefix),
+		Action:      app.Default(&cfg, runAction, app.ArgConverter(0, &cfg.Ref)),
+	}
+}
+
+// flags defines all command flags
+func flags(prefix app.AppEnvPrefix) []cli.Flag {
+	envPrefix := string(prefix) + "RESOURCE_DELETE_"
+
+	baseFlags := []cli.Flag{
+		&cli.BoolFlag{
+			Name:        flagDryRun,
+			Usage:       "Perform a dry run without deleting the resource",
+			EnvVars:     []string{envPrefix + "DRY_RUN"},
+			Value:       false,
+			Destination: &cfg.DryRun,
+		},
+		&cli.BoolFlag{
+			Name:        flagForce,
+			Aliases:     []string{"f"},
+			Usage:       "Delete permanently instead of moving to the trash",
+			EnvVars:     []string{envPrefix + "FORCE"},
+			Value:       false,
+			Destination: &cfg.Force,
+		},
+		&cli.BoolFlag{
+			Name:        flagCascade,
+			Usage:       "Also delete every resource that depends on it, directly or not",
+			EnvVars:     []string{envPrefix + "CASCADE"},
+			Value:       false,
+			Destination: &cfg.Cascade,
+		},
+		&cli.Int64Flag{
+			Name:        flagIfVersion,
+			Usage:       "Only delete if the resource is still at this version (exit code 5 otherwise)",
+			EnvVars:     []string{envPrefix + "IF_VERSION"},
+			Destination: (*int64)(&cfg.IfVersion),
+		},
+	}
+
+	baseFlags = app.WithNamespaceFlags(prefix, (*string)(&cfg.Namespace), baseFlags)
+	baseFlags = app.WithStateFlags(prefix, (*string)(&cfg.State), baseFlags)
+
+	return app.WithOutputFlags(prefix, &cfg.Output, baseFlags)
+}
@@ -0,0 +1,49 @@
+// Package restore implements the resource restore command
+package restore
+
+import (
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/resource/restore"
+	"github.com/urfave/cli/v2"
+)
+
+// Command metadata
+const (
+	Name        = "restore"
+	usage       = "Restore a deleted resource"
+	argsUsage   = "[options] <id|name>"
+	description = `Take a resource out of the trash.
+
+A resource is not restored while a live resource of its namespace uses its
+name (exit code 4); rename or delete that one first.
+
+Examples:
+  # Undo a delete
+  modern-go-application resource restore my-resource
+`
+)
+
+// Package-level config populated by urfave/cli via Destination
+var cfg restore.Config
+
+var runAction = restore.Run
+
+// Command returns the CLI command for restoring resources
+func Command(prefix app.AppEnvPrefix) *cli.Command {
+	return &cli.Command{
+		Name:        Name,
+		Usage:       usage,
+		ArgsUsage:   argsUsage,
+		Description: description,
+		Flags:       flags(prefix),
+		Action:      app.Default(&cfg, runAction, app.ArgConverter(0, &cfg.Ref)),
+	}
+}
+
+// flags defines all command flags
+func flags(prefix app.AppEnvPrefix) []cli.Flag {
+	baseFlags := app.WithNamespaceFlags(prefix, (*string)(&cfg.Namespace), nil)
+	baseFlags = app.WithStateFlags(prefix, (*string)(&cfg.State), baseFlags)
+
+	return app.WithOutputFlags(prefix, &cfg.Output, baseFlags)
+}
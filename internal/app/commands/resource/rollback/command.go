@@ -0,0 +1,82 @@
+// Package rollback implements the resource rollback command
+package rollback
+
+import (
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/resource/rollback"
+	"github.com/urfave/cli/v2"
+)
+
+// Command metadata
+const (
+	Name        = "rollback"
+	usage       = "Restore a resource to an earlier revision"
+	argsUsage   = "[options] <id|name>"
+	description = `Restore the name, description, status, tags, labels and enabled state of
+a resource from one of its revisions (see "resource history").
+
+The rollback is itself recorded as a new revision, so it can be undone. A
+status change is recorded as a transition. When the lifecycle does not allow
+it (see "resource transition"), the current status is kept, the rest is
+rolled back, and the result reports status_kept.
+
+Examples:
+  # Preview a rollback
+  modern-go-application resource rollback --to-revision 2 --dry-run my-resource
+
+  # Roll back
+  modern-go-application resource rollback --to-revision 2 my-resource
+`
+)
+
+// Flag names
+const (
+	flagToRevision = "to-revision"
+	flagDryRun     = "dry-run"
+)
+
+// Package-level config populated by urfave/cli via Destination
+var cfg rollback.Config
+
+var runAction = rollback.Run
+
+// Command returns the CLI command for rolling back resources
+func Command(prefix app.AppEnvPrefix) *cli.Command {
+	return &cli.Command{
+		Name:        Name,
+		Usage:       usage,
+		ArgsUsage:   argsUsage,
+		Description: description,
+		Flags:       flags(prefix),
+		Action:      app.Default(&cfg, runAction, app.ArgConverter(0, &cfg.Ref)),
+	}
+}
+
+// flags defines all command flags
+func flags(prefix app.AppEnvPrefix) []cli.Flag {
+	envPrefix := string(prefix) + "RESOURCE_ROLLBACK_"
+
+	baseFlags := []cli.Flag{
+		&cli.IntFlag{
+			Name:        flagToRevision,
+			Aliases:     []string{"r"},
+			Usage:       "Revision number to restore",
+			EnvVars:     []string{envPrefix + "TO_REVISION"},
+			Required:    true,
+			Destination: (*int)(&cfg.ToRevision),
+		},
+		&cli.BoolFlag{
+			Name:        flagDryRun,
+			Usage:       "Report the changes without applying them",
+			EnvVars:     []string{envPrefix + "DRY_RUN"},
+			Value:       false,
+			Destination: &cfg.DryRun,
+		},
+	}
+
+	baseFlags = app.WithActorFlags(prefix, (*string)(&cfg.Actor), baseFlags)
+	baseFlags = app.WithNamespaceFlags(prefix, (*string)(&cfg.Namespace), baseFlags)
+	baseFlags = app.WithStateFlags(prefix, (*string)(&cfg.State), baseFlags)
+
+	return app.WithOutputFlags(prefix, &cfg.Output, baseFlags)
+}
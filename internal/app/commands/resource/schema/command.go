@@ -0,0 +1,43 @@
+// Package schema provides the resource schema CLI command.
+package schema
+
+import (
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/app/commands/resource/schema/list"
+	"github.com/gomatic/modern-go-application/internal/app/commands/resource/schema/register"
+	"github.com/urfave/cli/v2"
+)
+
+// Command metadata
+const (
+	Name        = "schema"
+	usage       = "Manage the spec schemas of resource kinds"
+	argsUsage   = "[command]"
+	description = `Manage the JSON Schemas that resource specs are checked against.
+
+Each resource kind has one schema, stored under <state-dir>/schemas. Resources
+created or updated with --kind must have a spec matching the schema of their
+kind; resources without a kind accept any spec.
+
+Examples:
+  # Register the schema for databases
+  modern-go-application resource schema register --file database.schema.json database
+
+  # Show every registered schema
+  modern-go-application resource schema list
+`
+)
+
+// Command returns the CLI command for schema management (parent command)
+func Command(prefix app.AppEnvPrefix) *cli.Command {
+	return &cli.Command{
+		Name:        Name,
+		Usage:       usage,
+		ArgsUsage:   argsUsage,
+		Description: description,
+		Subcommands: []*cli.Command{
+			register.Command(prefix),
+			list.Command(prefix),
+		},
+	}
+}
@@ -0,0 +1,45 @@
+// Package list implements the resource schema list command
+package list
+
+import (
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/resource/schema/list"
+	"github.com/urfave/cli/v2"
+)
+
+// Command metadata
+const (
+	Name        = "list"
+	usage       = "List registered spec schemas"
+	argsUsage   = "[options]"
+	description = `List every registered resource kind with its JSON Schema.
+
+Examples:
+  # Show every registered schema
+  modern-go-application resource schema list
+`
+)
+
+// Package-level config populated by urfave/cli via Destination
+var cfg list.Config
+
+var runAction = list.Run
+
+// Command returns the CLI command for listing schemas
+func Command(prefix app.AppEnvPrefix) *cli.Command {
+	return &cli.Command{
+		Name:        Name,
+		Usage:       usage,
+		ArgsUsage:   argsUsage,
+		Description: description,
+		Flags:       flags(prefix),
+		Action:      app.Default(&cfg, runAction),
+	}
+}
+
+// flags defines all command flags
+func flags(prefix app.AppEnvPrefix) []cli.Flag {
+	baseFlags := app.WithStateFlags(prefix, (*string)(&cfg.State), nil)
+
+	return app.WithOutputFlags(prefix, &cfg.Output, baseFlags)
+}
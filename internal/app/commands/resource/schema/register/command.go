@@ -0,0 +1,64 @@
+// Package register implements the resource schema register command
+package register
+
+import (
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/resource/schema/register"
+	"github.com/urfave/cli/v2"
+)
+
+// Command metadata
+const (
+	Name        = "register"
+	usage       = "Register the spec schema of a kind"
+	argsUsage   = "[options] <kind>"
+	description = `Register a JSON Schema as the schema for a resource kind, replacing any
+previous one. The schema must compile. Existing resources of the kind whose
+spec does not match the new schema are listed as nonconforming.
+
+Examples:
+  # Require databases to declare capacity and region
+  modern-go-application resource schema register --file database.schema.json database
+`
+)
+
+// Flag names
+const (
+	flagFile = "file"
+)
+
+// Package-level config populated by urfave/cli via Destination
+var cfg register.Config
+
+var runAction = register.Run
+
+// Command returns the CLI command for registering a schema
+func Command(prefix app.AppEnvPrefix) *cli.Command {
+	return &cli.Command{
+		Name:        Name,
+		Usage:       usage,
+		ArgsUsage:   argsUsage,
+		Description: description,
+		Flags:       flags(prefix),
+		Action:      app.Default(&cfg, runAction, app.ArgConverter(0, &cfg.Kind)),
+	}
+}
+
+// flags defines all command flags
+func flags(prefix app.AppEnvPrefix) []cli.Flag {
+	envPrefix := string(prefix) + "RESOURCE_SCHEMA_REGISTER_"
+
+	baseFlags := []cli.Flag{
+		&cli.StringFlag{
+			Name:        flagFile,
+			Aliases:     []string{"f"},
+			Usage:       "JSON Schema file",
+			EnvVars:     []string{envPrefix + "FILE"},
+			Destination: (*string)(&cfg.File),
+		},
+	}
+
+	baseFlags = app.WithStateFlags(prefix, (*string)(&cfg.State), baseFlags)
+
+	return app.WithOutputFlags(prefix, &cfg.Output, baseFlags)
+}
@@ -0,0 +1,85 @@
+// Package search implements the resource search command
+package search
+
+import (
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/resource/search"
+	"github.com/urfave/cli/v2"
+)
+
+// Command metadata
+const (
+	Name        = "search"
+	usage       = "Search resources by name, description and tags"
+	argsUsage   = "[options] <query>"
+	description = `Rank resources by how well their name, description and tags match a query.
+
+Every term of the query must match. Words match whole words, ignoring case
+and punctuation; a trailing * matches by prefix and "double quotes" match a
+phrase. Prefix a term with name:, description: (or desc:) or tag: to search
+only that field. Matches in the name count most, then tags, then the
+description, and rare words count for more than common ones. Each hit
+carries its score and the fields that matched. Options must precede the query.
+
+Examples:
+  # Find resources about payments
+  modern-go-application resource search payment
+
+  # Phrase and prefix matching
+  modern-go-application resource search '"payment api"' web*
+
+  # Restrict terms to fields
+  modern-go-application resource search tag:prod name:db*
+`
+)
+
+// Flag names
+const (
+	flagLimit   = "limit"
+	flagDeleted = "include-deleted"
+)
+
+// Package-level config populated by urfave/cli via Destination
+var cfg search.Config
+
+var runAction = search.Run
+
+// Command returns the CLI command for searching resources
+func Command(prefix app.AppEnvPrefix) *cli.Command {
+	return &cli.Command{
+		Name:        Name,
+		Usage:       usage,
+		ArgsUsage:   argsUsage,
+		Description: description,
+		Flags:       flags(prefix),
+		Action:      app.Default(&cfg, runAction, app.JoinedArgsConverter(0, &cfg.Query)),
+	}
+}
+
+// flags defines all command flags
+func flags(prefix app.AppEnvPrefix) []cli.Flag {
+	envPrefix := string(prefix) + "RESOURCE_SEARCH_"
+
+	baseFlags := []cli.Flag{
+		&cli.IntFlag{
+			Name:        flagLimit,
+			Aliases:     []string{"l"},
+			Usage:       "Maximum number of hits (0 = unlimited)",
+			EnvVars:     []string{envPrefix + "LIMIT"},
+			Value:       10,
+			Destination: (*int)(&cfg.Limit),
+		},
+		&cli.BoolFlag{
+			Name:        flagDeleted,
+			Usage:       "Include resources in the trash",
+			EnvVars:     []string{envPrefix + "INCLUDE_DELETED"},
+			Value:       false,
+			Destination: &cfg.IncludeDeleted,
+		},
+	}
+
+	baseFlags = app.WithNamespaceFlags(prefix, (*string)(&cfg.Namespace), baseFlags)
+	baseFlags = app.WithStateFlags(prefix, (*string)(&cfg.State), baseFlags)
+
+	return app.WithOutputFlags(prefix, &cfg.Output, baseFlags)
+}
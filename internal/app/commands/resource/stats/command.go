@@ -0,0 +1,87 @@
+// Package stats implements the resource stats command
+package stats
+
+import (
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/app/commands/resource/list"
+	"github.com/gomatic/modern-go-application/internal/resource/stats"
+	"github.com/urfave/cli/v2"
+)
+
+// Command metadata
+const (
+	Name        = "stats"
+	usage       = "Count resources by status, tag, creation day or namespace"
+	argsUsage   = "[options]"
+	description = `Count resources by status, tag, creation day (UTC) or namespace.
+
+Takes the same filters as "resource list" and counts the resources that
+match them. A resource with several tags counts towards each tag; resources
+without tags are counted under "(none)".
+
+Examples:
+  # Count resources by status
+  modern-go-application resource stats
+
+  # Share of each tag among active resources
+  modern-go-application resource stats --group-by tag --status active --percentages
+
+  # Resources created per day over the last week
+  modern-go-application resource stats --group-by created_day --created-after 7d
+
+  # Resources per namespace
+  modern-go-application resource stats --group-by namespace --all-namespaces
+`
+)
+
+// Flag names
+const (
+	flagGroupBy     = "group-by"
+	flagPercentages = "percentages"
+)
+
+// Package-level config populated by urfave/cli via Destination
+var cfg stats.Config
+
+var runAction = stats.Run
+
+// Command returns the CLI command for counting resources
+func Command(prefix app.AppEnvPrefix) *cli.Command {
+	return &cli.Command{
+		Name:        Name,
+		Usage:       usage,
+		ArgsUsage:   argsUsage,
+		Description: description,
+		Flags:       flags(prefix),
+		Action:      app.Default(&cfg, runAction, list.FilterConverter(&cfg.Filter)),
+	}
+}
+
+// flags defines all command flags
+func flags(prefix app.AppEnvPrefix) []cli.Flag {
+	envPrefix := string(prefix) + "RESOURCE_STATS_"
+
+	baseFlags := append([]cli.Flag{
+		&cli.StringFlag{
+			Name:        flagGroupBy,
+			Aliases:     []string{"g"},
+			Usage:       "Property to count by (status, tag, created_day, namespace)",
+			EnvVars:     []string{envPrefix + "GROUP_BY"},
+			Value:       string(stats.GroupByStatus),
+			Destination: (*string)(&cfg.GroupBy),
+		},
+		&cli.BoolFlag{
+			Name:        flagPercentages,
+			Aliases:     []string{"p"},
+			Usage:       "Include each bucket's share of the total",
+			EnvVars:     []string{envPrefix + "PERCENTAGES"},
+			Value:       false,
+			Destination: &cfg.Percentages,
+		},
+	}, list.FilterFlags(envPrefix, &cfg.Filter)...)
+
+	baseFlags = app.WithNamespaceFlags(prefix, (*string)(&cfg.Namespace), baseFlags)
+	baseFlags = app.WithStateFlags(prefix, (*string)(&cfg.State), baseFlags)
+
+	return app.WithOutputFlags(prefix, &cfg.Output, baseFlags)
+}
@@ -0,0 +1,56 @@
+// Package template provides the resource template CLI command.
+package template
+
+import (
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/app/commands/resource/template/list"
+	"github.com/urfave/cli/v2"
+)
+
+// Command metadata
+const (
+	Name        = "template"
+	usage       = "Manage resource templates"
+	argsUsage   = "[command]"
+	description = `Manage the templates that "resource create --template" takes defaults from.
+
+Templates are JSON files stored under <state-dir>/templates, named
+<name>.json. A template may set the name, description, kind, tags, labels,
+enabled state and spec of a resource; its strings may hold {{ .var }}
+placeholders, and "vars" gives default values for them:
+
+  {
+    "name": "{{ .name }}",
+    "description": "Web service {{ .name }}",
+    "kind": "service",
+    "tags": ["web", "{{ .env }}"],
+    "enabled": true,
+    "spec": {"port": "{{ json .port }}", "version": "{{ .version }}"},
+    "vars": {"env": "dev", "port": "80", "version": "1.10"}
+  }
+
+Spec strings stay strings, so "version" above is "1.10". A spec string that
+is a single placeholder piped through json, as "port" above, is replaced by
+the JSON value it renders to, here the number 80.
+
+Examples:
+  # Show every template
+  modern-go-application resource template list
+
+  # Create a resource from a template
+  modern-go-application resource create --template web-service --var name=checkout
+`
+)
+
+// Command returns the CLI command for template management (parent command)
+func Command(prefix app.AppEnvPrefix) *cli.Command {
+	return &cli.Command{
+		Name:        Name,
+		Usage:       usage,
+		ArgsUsage:   argsUsage,
+		Description: description,
+		Subcommands: []*cli.Command{
+			list.Command(prefix),
+		},
+	}
+}
@@ -0,0 +1,46 @@
+// Package list implements the resource template list command
+package list
+
+import (
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/resource/template/list"
+	"github.com/urfave/cli/v2"
+)
+
+// Command metadata
+const (
+	Name        = "list"
+	usage       = "List resource templates"
+	argsUsage   = "[options]"
+	description = `List every template in the templates directory with the variables it
+takes and their default values.
+
+Examples:
+  # Show every template
+  modern-go-application resource template list
+`
+)
+
+// Package-level config populated by urfave/cli via Destination
+var cfg list.Config
+
+var runAction = list.Run
+
+// Command returns the CLI command for listing templates
+func Command(prefix app.AppEnvPrefix) *cli.Command {
+	return &cli.Command{
+		Name:        Name,
+		Usage:       usage,
+		ArgsUsage:   argsUsage,
+		Description: description,
+		Flags:       flags(prefix),
+		Action:      app.Default(&cfg, runAction),
+	}
+}
+
+// flags defines all command flags
+func flags(prefix app.AppEnvPrefix) []cli.Flag {
+	baseFlags := app.WithStateFlags(prefix, (*string)(&cfg.State), nil)
+
+	return app.WithOutputFlags(prefix, &cfg.Output, baseFlags)
+}
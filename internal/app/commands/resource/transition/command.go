@@ -0,0 +1,75 @@
+// Package transition implements the resource transition command
+package transition
+
+import (
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/resource/transition"
+	"github.com/urfave/cli/v2"
+)
+
+// Command metadata
+const (
+	Name        = "transition"
+	usage       = "Move a resource to a new status"
+	argsUsage   = "[options] <id|name>"
+	description = `Move a resource to a new status along the lifecycle:
+
+  pending  -> active, inactive
+  active   -> inactive
+  inactive -> active
+
+Other moves are rejected. Every transition is recorded on the resource with
+the acting user and time.
+
+Examples:
+  # Activate a pending resource
+  modern-go-application resource transition --to active my-resource
+
+  # Record who made the change
+  modern-go-application resource transition --to inactive --actor ops-bot my-resource
+`
+)
+
+// Flag names
+const (
+	flagTo = "to"
+)
+
+// Package-level config populated by urfave/cli via Destination
+var cfg transition.Config
+
+var runAction = transition.Run
+
+// Command returns the CLI command for status transitions
+func Command(prefix app.AppEnvPrefix) *cli.Command {
+	return &cli.Command{
+		Name:        Name,
+		Usage:       usage,
+		ArgsUsage:   argsUsage,
+		Description: description,
+		Flags:       flags(prefix),
+		Action:      app.Default(&cfg, runAction, app.ArgConverter(0, &cfg.Ref)),
+	}
+}
+
+// flags defines all command flags
+func flags(prefix app.AppEnvPrefix) []cli.Flag {
+	envPrefix := string(prefix) + "RESOURCE_TRANSITION_"
+
+	baseFlags := []cli.Flag{
+		&cli.StringFlag{
+			Name:        flagTo,
+			Aliases:     []string{"t"},
+			Usage:       "Target status (active, inactive)",
+			EnvVars:     []string{envPrefix + "TO"},
+			Required:    true,
+			Destination: (*string)(&cfg.To),
+		},
+	}
+
+	baseFlags = app.WithActorFlags(prefix, (*string)(&cfg.Actor), baseFlags)
+	baseFlags = app.WithNamespaceFlags(prefix, (*string)(&cfg.Namespace), baseFlags)
+	baseFlags = app.WithStateFlags(prefix, (*string)(&cfg.State), baseFlags)
+
+	return app.WithOutputFlags(prefix, &cfg.Output, baseFlags)
+}
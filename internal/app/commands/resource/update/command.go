@@ -0,0 +1,180 @@
+// Package update implements the resource update command
+package update
+
+import (
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/resource/update"
+	"github.com/urfave/cli/v2"
+)
+
+// Command metadata
+const (
+	Name        = "update"
+	usage       = "Update an existing resource"
+	argsUsage   = "[options] <id|name>"
+	description = `Update the description, enabled state, status, tags, labels, spec or dependencies
+of a resource.
+
+Only the fields given on the command line are changed. Tags, labels and
+dependencies are added and removed individually rather than replaced. The result reports the before
+and after value of every changed field. Options must precede the ID or name.
+
+Examples:
+  # Change the description and disable the resource
+  modern-go-application resource update \
+    --description "Retired resource" \
+    --enabled=false \
+    my-resource
+
+  # Add and remove tags
+  modern-go-application resource update \
+    --add-tag prod --add-tag critical \
+    --remove-tag staging \
+    --label tier=db --remove-label owner \
+    res-01jh5v6k2m8q9r3s4t5v6w7x8y
+
+  # Change spec values; the result must still match the kind's schema
+  modern-go-application resource update --set capacity=200 --unset maintenance my-resource
+
+  # Depend on another resource; changes that would create a cycle are rejected
+  modern-go-application resource update --add-dependency db-1 --remove-dependency cache web
+
+  # Fail instead of overwriting someone else's change
+  modern-go-application resource update --if-version 3 --enabled my-resource
+`
+)
+
+// Flag names
+const (
+	flagDescription = "description"
+	flagEnabled     = "enabled"
+	flagStatus      = "status"
+	flagAddTag      = "add-tag"
+	flagRemoveTag   = "remove-tag"
+	flagLabel       = "label"
+	flagRemoveLabel = "remove-label"
+	flagSpecFile    = "spec-file"
+	flagSet         = "set"
+	flagUnset       = "unset"
+	flagAddDep      = "add-dependency"
+	flagRemoveDep   = "remove-dependency"
+	flagIfVersion   = "if-version"
+)
+
+// Package-level config populated by urfave/cli via Destination
+var cfg update.Config
+
+var runAction = update.Run
+
+// Command returns the CLI command for updating resources
+func Command(prefix app.AppEnvPrefix) *cli.Command {
+	return &cli.Command{
+		Name:        Name,
+		Usage:       usage,
+		ArgsUsage:   argsUsage,
+		Description: description,
+		Flags:       flags(prefix),
+		Action: app.Default(&cfg, runAction,
+			app.ArgConverter(0, &cfg.Ref),
+			app.IsSetConverter(flagDescription, &cfg.SetDescription),
+			app.IsSetConverter(flagEnabled, &cfg.SetEnabled),
+			app.StringSliceConverter(flagAddTag, &cfg.AddTags),
+			app.StringSliceConverter(flagRemoveTag, &cfg.RemoveTags),
+			app.StringSliceConverter(flagLabel, &cfg.SetLabels),
+			app.StringSliceConverter(flagRemoveLabel, &cfg.RemoveLabels),
+			app.RepeatedConverter(flagSet, &cfg.Spec),
+			app.StringSliceConverter(flagUnset, &cfg.UnsetSpec),
+			app.StringSliceConverter(flagAddDep, &cfg.AddDeps),
+			app.StringSliceConverter(flagRemoveDep, &cfg.RemoveDeps),
+		),
+	}
+}
+
+// flags defines all command flags
+func flags(prefix app.AppEnvPrefix) []cli.Flag {
+	envPrefix := string(prefix) + "RESOURCE_UPDATE_"
+
+	baseFlags := []cli.Flag{
+		&cli.StringFlag{
+			Name:        flagDescription,
+			Aliases:     []string{"desc"},
+			Usage:       "New resource description",
+			EnvVars:     []string{envPrefix + "DESCRIPTION"},
+			Destination: (*string)(&cfg.Description),
+		},
+		&cli.BoolFlag{
+			Name:        flagEnabled,
+			Aliases:     []string{"e"},
+			Usage:       "Enable or disable the resource (--enabled=false to disable)",
+			EnvVars:     []string{envPrefix + "ENABLED"},
+			Destination: &cfg.Enabled,
+		},
+		&cli.StringFlag{
+			Name:        flagStatus,
+			Aliases:     []string{"s"},
+			Usage:       "New resource status; must be reachable from the current one (see resource transition)",
+			EnvVars:     []string{envPrefix + "STATUS"},
+			Destination: (*string)(&cfg.Status),
+		},
+		&cli.StringSliceFlag{
+			Name:    flagAddTag,
+			Usage:   "Tag to add (can be specified multiple times or comma-separated)",
+			EnvVars: []string{envPrefix + "ADD_TAGS"},
+		},
+		&cli.StringSliceFlag{
+			Name:    flagRemoveTag,
+			Usage:   "Tag to remove (can be specified multiple times or comma-separated)",
+			EnvVars: []string{envPrefix + "REMOVE_TAGS"},
+		},
+		&cli.StringSliceFlag{
+			Name:    flagLabel,
+			Aliases: []string{"l"},
+			Usage:   "Label to add or overwrite as key=value (can be specified multiple times)",
+			EnvVars: []string{envPrefix + "LABELS"},
+		},
+		&cli.StringSliceFlag{
+			Name:    flagRemoveLabel,
+			Usage:   "Label key to remove (can be specified multiple times or comma-separated)",
+			EnvVars: []string{envPrefix + "REMOVE_LABELS"},
+		},
+		&cli.StringFlag{
+			Name:        flagSpecFile,
+			Usage:       "JSON file replacing the whole spec (applied before --set and --unset)",
+			EnvVars:     []string{envPrefix + "SPEC_FILE"},
+			Destination: (*string)(&cfg.SpecFile),
+		},
+		&cli.GenericFlag{
+			Name:    flagSet,
+			Usage:   "Spec value to set as path=value (can be specified multiple times; not split on commas)",
+			EnvVars: []string{envPrefix + "SPEC"},
+			Value:   &app.Repeated{},
+		},
+		&cli.StringSliceFlag{
+			Name:    flagUnset,
+			Usage:   "Spec path to remove (can be specified multiple times or comma-separated)",
+			EnvVars: []string{envPrefix + "UNSET_SPEC"},
+		},
+		&cli.StringSliceFlag{
+			Name:    flagAddDep,
+			Usage:   "ID or name of a resource to depend on (can be specified multiple times or comma-separated)",
+			EnvVars: []string{envPrefix + "ADD_DEPENDENCIES"},
+		},
+		&cli.StringSliceFlag{
+			Name:    flagRemoveDep,
+			Usage:   "ID or name of a resource to stop depending on (can be specified multiple times or comma-separated)",
+			EnvVars: []string{envPrefix + "REMOVE_DEPENDENCIES"},
+		},
+		&cli.Int64Flag{
+			Name:        flagIfVersion,
+			Usage:       "Only update if the resource is still at this version (exit code 5 otherwise)",
+			EnvVars:     []string{envPrefix + "IF_VERSION"},
+			Destination: (*int64)(&cfg.IfVersion),
+		},
+	}
+
+	baseFlags = app.WithActorFlags(prefix, (*string)(&cfg.Actor), baseFlags)
+	baseFlags = app.WithNamespaceFlags(prefix, (*string)(&cfg.Namespace), baseFlags)
+	baseFlags = app.WithStateFlags(prefix, (*string)(&cfg.State), baseFlags)
+
+	return app.WithOutputFlags(prefix, &cfg.Output, baseFlags)
+}
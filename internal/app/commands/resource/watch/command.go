@@ -0,0 +1,89 @@
+// Package watch implements the resource watch command
+package watch
+
+import (
+	"slices"
+	"time"
+
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/app/commands/resource/list"
+	"github.com/gomatic/modern-go-application/internal/resource/watch"
+	"github.com/urfave/cli/v2"
+)
+
+// Command metadata
+const (
+	Name        = "watch"
+	usage       = "Stream resource changes as NDJSON"
+	argsUsage   = "[options]"
+	description = `Write one JSON event per line for every change made to the store
+until interrupted. Each event has a type (created, updated or deleted), the
+resource as it was after the change, and the revision that recorded it.
+
+Only changes made after the watch starts are reported. The same filters as
+"resource list" apply to the resource after each change, with relative times
+resolved when the watch starts. Moves to and from the trash are always
+reported, so there is no --include-deleted.
+
+Examples:
+  # Follow every change
+  modern-go-application resource watch
+
+  # Follow production web resources, checking twice a second
+  modern-go-application resource watch --include "web-*" --status active --interval 500ms
+
+  # Follow labelled resources in every namespace
+  modern-go-application resource watch -A --selector 'env=prod' --where '"web" in tags'
+
+  # React to deletions
+  modern-go-application resource watch | jq -c 'select(.type == "deleted")'
+`
+)
+
+// Flag names
+const (
+	flagDeleted  = "include-deleted"
+	flagInterval = "interval"
+)
+
+// Package-level config populated by urfave/cli via Destination
+var cfg watch.Config
+
+var runAction = watch.Run
+
+// Command returns the CLI command for watching resources
+func Command(prefix app.AppEnvPrefix) *cli.Command {
+	return &cli.Command{
+		Name:        Name,
+		Usage:       usage,
+		ArgsUsage:   argsUsage,
+		Description: description,
+		Flags:       flags(prefix),
+		Action:      app.Default(&cfg, runAction, list.FilterConverter(&cfg.Filter)),
+	}
+}
+
+// flags defines all command flags
+func flags(prefix app.AppEnvPrefix) []cli.Flag {
+	envPrefix := string(prefix) + "RESOURCE_WATCH_"
+
+	// Changes to resources in the trash are always reported.
+	filterFlags := slices.DeleteFunc(list.FilterFlags(envPrefix, &cfg.Filter), func(f cli.Flag) bool {
+		return slices.Contains(f.Names(), flagDeleted)
+	})
+
+	baseFlags := append(filterFlags,
+		&cli.DurationFlag{
+			Name:        flagInterval,
+			Usage:       "How often to check the store for changes",
+			EnvVars:     []string{envPrefix + "INTERVAL"},
+			Value:       time.Second,
+			Destination: &cfg.Interval,
+		},
+	)
+
+	baseFlags = app.WithNamespaceFlags(prefix, (*string)(&cfg.Namespace), baseFlags)
+	baseFlags = app.WithStateFlags(prefix, (*string)(&cfg.State), baseFlags)
+
+	return app.WithOutputFlags(prefix, &cfg.Output, baseFlags)
+}
@@ -81,7 +81,7 @@ func Command(prefix app.AppEnvPrefix) *cli.Command {
 		ArgsUsage:   argsUsage,
 		Description: description,
 		Flags:       flags(prefix),
-		Action:      app.Default(cfg, runAction),
+		Action:      app.Default(&cfg, runAction),
 	}
 }
 
@@ -65,7 +65,7 @@ func Command(prefix app.AppEnvPrefix) *cli.Command {
 		ArgsUsage:   argsUsage,
 		Description: description,
 		Flags:       flags(prefix),
-		Action:      app.Default(cfg, runAction, app.IntSliceConverter(flagPID, &cfg.PIDs)),
+		Action:      app.Default(&cfg, runAction, app.IntSliceConverter(flagPID, &cfg.PIDs)),
 	}
 }
 
@@ -1,6 +1,8 @@
 package app
 
 import (
+	"strings"
+
 	"github.com/urfave/cli/v2"
 )
 
@@ -76,3 +78,55 @@ func WithFilterFlags(prefix AppEnvPrefix, includePatterns *string, excludePatter
 
 	return append(flags, filterFlags...)
 }
+
+// WithStateFlags appends the state directory flag to the provided flag list
+func WithStateFlags(prefix AppEnvPrefix, stateDir *string, flags []cli.Flag) []cli.Flag {
+	return append(flags, &cli.StringFlag{
+		Name:        "state-dir",
+		Usage:       "Directory holding persisted state (default: $XDG_STATE_HOME/mga)",
+		EnvVars:     []string{string(prefix) + "STATE_DIR"},
+		Destination: stateDir,
+	})
+}
+
+// WithNamespaceFlags appends the namespace flag, which scopes a command to one namespace, to the provided flag list
+func WithNamespaceFlags(prefix AppEnvPrefix, namespace *string, flags []cli.Flag) []cli.Flag {
+	return append(flags, &cli.StringFlag{
+		Name:        "namespace",
+		Aliases:     []string{"ns"},
+		Usage:       "Namespace of the resources",
+		EnvVars:     []string{string(prefix) + "NAMESPACE"},
+		Value:       "default",
+		Destination: namespace,
+	})
+}
+
+// WithActorFlags appends the actor flag, which names who is making a change, to the provided flag list
+func WithActorFlags(prefix AppEnvPrefix, actor *string, flags []cli.Flag) []cli.Flag {
+	return append(flags, &cli.StringFlag{
+		Name:        "actor",
+		Usage:       "Name recorded as performing the change",
+		EnvVars:     []string{string(prefix) + "ACTOR", "USER"},
+		Destination: actor,
+	})
+}
+
+// Repeated is the value of a flag that may be given more than once, for use
+// with cli.GenericFlag. Unlike cli.StringSliceFlag it keeps each value whole
+// rather than splitting it on commas, so values may hold JSON such as
+// zones=["a","b"]. Read it with RepeatedConverter.
+type Repeated []string
+
+// Set implements cli.Generic
+func (r *Repeated) Set(value string) error {
+	*r = append(*r, value)
+	return nil
+}
+
+// String implements cli.Generic
+func (r *Repeated) String() string {
+	if r == nil {
+		return ""
+	}
+	return strings.Join(*r, " ")
+}
@@ -0,0 +1,26 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+)
+
+func TestRepeatedConverter(t *testing.T) {
+	type assignment string
+	var got []assignment
+	cliApp := &cli.App{
+		Flags: []cli.Flag{&cli.GenericFlag{Name: "set", Value: &Repeated{}}},
+		Action: func(c *cli.Context) error {
+			RepeatedConverter("set", &got).Convert(c)
+			return nil
+		},
+	}
+	if err := cliApp.Run([]string{"mga", "--set", `zones=["a","b"]`, "--set", "cpu=2"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if want := []assignment{`zones=["a","b"]`, "cpu=2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
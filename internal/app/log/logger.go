@@ -31,7 +31,9 @@ type Config struct {
 // GetLoggerFunc is a function type for getting a logger
 type GetLoggerFunc func(*cli.Context, Config) *slog.Logger
 
-// GetLogger creates and configures a logger based on the provided configuration
+// GetLogger creates and configures a logger based on the provided configuration.
+// Logs go to stderr, keeping stdout for command results so that they can be
+// piped, as in "resource export | resource import --file -".
 func GetLogger(c *cli.Context, cfg Config) *slog.Logger {
 	// Get logger from metadata if it already exists
 	if logger, ok := c.App.Metadata[LoggerMetadataKey].(*slog.Logger); ok {
@@ -50,11 +52,11 @@ func GetLogger(c *cli.Context, cfg Config) *slog.Logger {
 
 	switch cfg.Format {
 	case JSONFormat:
-		handler = slog.NewJSONHandler(os.Stdout, opts)
+		handler = slog.NewJSONHandler(os.Stderr, opts)
 	case TextFormat:
 		fallthrough
 	default:
-		handler = slog.NewTextHandler(os.Stdout, opts)
+		handler = slog.NewTextHandler(os.Stderr, opts)
 	}
 
 	return slog.New(handler)
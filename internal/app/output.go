@@ -1,14 +1,46 @@
 package app
 
 import (
+	"bytes"
 	"encoding/json"
+	"io"
 	"log/slog"
 	"os"
 )
 
+// Renderer is implemented by results that are written in a format other than
+// indented JSON. Render returns the complete output.
+type Renderer interface {
+	Render() ([]byte, error)
+}
+
+// Streamer is implemented by results whose output was written by the runner
+// as it ran, using OpenOutput. Output writes nothing for them.
+type Streamer interface {
+	Streamed()
+}
+
+// OpenOutput returns a writer for stdout or, if filePath is set, a new file.
+// Closing the writer for stdout leaves stdout open.
+func OpenOutput(logger *slog.Logger, filePath FilePath) (io.WriteCloser, error) {
+	if filePath == "" {
+		return nopCloser{os.Stdout}, nil
+	}
+	logger.Info("Writing output to file", "path", filePath)
+	return os.OpenFile(string(filePath), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+}
+
+type nopCloser struct{ io.Writer }
+
+func (nopCloser) Close() error { return nil }
+
 // Output writes the result to stdout or a file
 func Output(logger *slog.Logger, filePath FilePath, result json.Marshaler) error {
-	data, err := json.MarshalIndent(result, "", "  ")
+	if _, ok := result.(Streamer); ok {
+		return nil
+	}
+
+	data, err := render(result)
 	if err != nil {
 		return err
 	}
@@ -27,3 +59,12 @@ func Output(logger *slog.Logger, filePath FilePath, result json.Marshaler) error
 	logger.Info("Writing output to file", "path", filePath)
 	return os.WriteFile(string(filePath), append(data, '\n'), 0o600)
 }
+
+// render encodes the result, trimming the trailing newline a Renderer may add.
+func render(result json.Marshaler) ([]byte, error) {
+	if r, ok := result.(Renderer); ok {
+		data, err := r.Render()
+		return bytes.TrimSuffix(data, []byte("\n")), err
+	}
+	return json.MarshalIndent(result, "", "  ")
+}
@@ -0,0 +1,20 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// Stopped ends a runner that keeps going until interrupted, such as watch or
+// reap --interval. An err that is nil or the cancellation of ctx is a clean
+// stop: Stopped logs msg with args and returns result. Any other err is
+// returned as is.
+func Stopped[R any](ctx context.Context, logger *slog.Logger, result R, err error, msg string, args ...any) (R, error) {
+	if err != nil && (ctx.Err() == nil || !errors.Is(err, ctx.Err())) {
+		var zero R
+		return zero, err
+	}
+	logger.Info(msg, args...)
+	return result, nil
+}
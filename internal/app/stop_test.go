@@ -0,0 +1,40 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+)
+
+func TestStopped(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	fail := errors.New("fail")
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		err     error
+		want    int
+		wantErr error
+	}{
+		{"finished", context.Background(), nil, 1, nil},
+		{"cancelled", cancelled, cancelled.Err(), 1, nil},
+		{"wrapped cancellation", cancelled, fmt.Errorf("poll: %w", context.Canceled), 1, nil},
+		{"failed", context.Background(), fail, 0, fail},
+		{"failed while cancelled", cancelled, fail, 0, fail},
+		{"cancellation of another context", context.Background(), context.Canceled, 0, context.Canceled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Stopped(tt.ctx, logger, 1, tt.err, "stopped")
+			if got != tt.want || !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Errorf("Stopped = %d, %v; want %d, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
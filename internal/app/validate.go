@@ -0,0 +1,62 @@
+package app
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// ExitCodeInvalid is the process exit code for configurations that fail validation.
+const ExitCodeInvalid = 2
+
+// Validator is implemented by configurations that can check themselves before running.
+// Action calls Validate on any config implementing it.
+type Validator interface {
+	Validate() error
+}
+
+// Violation describes a single invalid field.
+type Violation struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError lists every violation found in a configuration.
+type ValidationError struct {
+	Violations []Violation `json:"violations"`
+}
+
+// Check records a violation for field if err is non-nil.
+func (e *ValidationError) Check(field string, err error) {
+	if err != nil {
+		e.Violations = append(e.Violations, Violation{Field: field, Message: err.Error()})
+	}
+}
+
+// Err returns e if any violations were recorded, nil otherwise.
+func (e *ValidationError) Err() error {
+	if len(e.Violations) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		parts[i] = v.Field + ": " + v.Message
+	}
+	return fmt.Sprintf("invalid configuration: %s", strings.Join(parts, "; "))
+}
+
+// ExitCode implements cli.ExitCoder.
+func (e *ValidationError) ExitCode() int { return ExitCodeInvalid }
+
+// LogValue implements slog.LogValuer so structured logs carry one attribute per violation.
+func (e *ValidationError) LogValue() slog.Value {
+	attrs := make([]slog.Attr, len(e.Violations))
+	for i, v := range e.Violations {
+		attrs[i] = slog.String(v.Field, v.Message)
+	}
+	return slog.GroupValue(attrs...)
+}
@@ -1,22 +1,87 @@
 package create
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/gomatic/modern-go-application/internal/app"
 	"github.com/gomatic/modern-go-application/internal/app/log"
 	"github.com/gomatic/modern-go-application/internal/resource"
 )
 
-// Config holds configuration for resource creation
+// Config holds configuration for resource creation. Values given here
+// override those of the template, if any.
 type Config struct {
-	Name        resource.Name        // Resource name
-	Description resource.Description // Resource description
-	Tags        []resource.Tag       // Resource tags
-	Enabled     bool                 // Whether resource is enabled (bool example)
-	DryRun      bool                 // Dry run mode
-	Force       bool                 // Force creation
-	Output      app.FilePath         // Output file path
-	Logging     log.Config
+	Template       resource.TemplateRef      // Template providing default values
+	Vars           []resource.TemplateVar    // Template variables (name=value)
+	Name           resource.Name             // Resource name
+	Kind           resource.Kind             // Resource kind, selecting the spec schema
+	Description    resource.Description      // Resource description
+	SetDescription bool                      // Whether the description was given
+	Tags           []resource.Tag            // Resource tags
+	Labels         []resource.Label          // Resource labels (key=value)
+	SpecFile       app.FilePath              // JSON file holding the initial spec
+	Spec           []resource.SpecAssignment // Spec values (path=value), applied over SpecFile
+	DependsOn      []resource.Ref            // Resources (ID or name) this one depends on
+	TTL            resource.TTL              // Time until the resource expires (0 = never)
+	ExpiresAt      resource.TimeSpec         // Absolute expiry time, instead of TTL
+	Enabled        bool                      // Whether resource is enabled (bool example)
+	SetEnabled     bool                      // Whether the enabled state was given
+	DryRun         bool                      // Dry run mode
+	Force          bool                      // Force creation
+	Actor          resource.Actor            // Who is making the change
+	Namespace      resource.Namespace        // Namespace of the resource
+	State          resource.StateDir         // Resource store directory
+	Output         app.FilePath              // Output file path
+	Logging        log.Config
 }
 
 func (c Config) OutputFilePath() app.FilePath { return c.Output }
 func (c Config) LoggerConfig() log.Config     { return c.Logging }
+
+// Validate implements app.Validator. The name may be left to the template.
+func (c Config) Validate() error {
+	return c.validate(c.Template != "" && c.Name == "")
+}
+
+// validate checks the configuration, skipping the name when it is to come
+// from a template
+func (c Config) validate(skipName bool) error {
+	var v app.ValidationError
+	v.Check("namespace", c.Namespace.Validate())
+	for i, variable := range c.Vars {
+		v.Check(fmt.Sprintf("var[%d]", i), variable.Validate())
+	}
+	if !skipName {
+		v.Check("name", c.Name.Validate())
+	}
+	v.Check("kind", c.Kind.Validate())
+	v.Check("description", c.Description.Validate())
+	for i, tag := range c.Tags {
+		v.Check(fmt.Sprintf("tags[%d]", i), tag.Validate())
+	}
+	for i, label := range c.Labels {
+		v.Check(fmt.Sprintf("label[%d]", i), label.Validate())
+	}
+	for i, a := range c.Spec {
+		v.Check(fmt.Sprintf("set[%d]", i), a.Validate())
+	}
+	for i, ref := range c.DependsOn {
+		v.Check(fmt.Sprintf("depends-on[%d]", i), ref.Validate())
+	}
+	if c.TTL < 0 {
+		v.Check("ttl", errors.New("must not be negative"))
+	}
+	if c.ExpiresAt != "" {
+		now := time.Now()
+		if c.TTL != 0 {
+			v.Check("expires-at", errors.New("cannot be combined with --ttl"))
+		} else if at, err := c.ExpiresAt.Resolve(now); err != nil {
+			v.Check("expires-at", err)
+		} else if !at.After(now) {
+			v.Check("expires-at", fmt.Errorf("%s is not in the future; use --ttl for a duration from now", at.Format(time.RFC3339)))
+		}
+	}
+	return v.Err()
+}
@@ -4,8 +4,11 @@ package create
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log/slog"
-	"strings"
+	"maps"
+	"slices"
+	"time"
 
 	"github.com/gomatic/modern-go-application/internal/resource"
 )
@@ -14,12 +17,21 @@ import (
 type Result struct {
 	Success     bool                 `json:"success"`
 	ResourceID  resource.ID          `json:"resource_id"`
+	Namespace   resource.Namespace   `json:"namespace"`
+	Version     resource.Version     `json:"version"`
 	Name        resource.Name        `json:"name"`
+	Kind        resource.Kind        `json:"kind,omitempty"`
+	Template    resource.TemplateRef `json:"template,omitempty"`
 	Description resource.Description `json:"description"`
 	Tags        []resource.Tag       `json:"tags"`
+	Labels      resource.Labels      `json:"labels,omitempty"`
+	Spec        resource.Spec        `json:"spec,omitempty"`
+	DependsOn   []resource.ID        `json:"depends_on,omitempty"`
+	ExpiresAt   *resource.ExpiresAt  `json:"expires_at,omitempty"`
 	Enabled     bool                 `json:"enabled"`
 	DryRun      bool                 `json:"dry_run"`
 	Force       bool                 `json:"force"`
+	Overwritten bool                 `json:"overwritten"`
 	Message     resource.Message     `json:"message"`
 }
 
@@ -29,33 +41,258 @@ func (r Result) MarshalJSON() ([]byte, error) {
 	return json.Marshal((Alias)(r))
 }
 
-// Run executes the resource creation logic (noop stub)
+// openStore opens the resource store.
+var openStore resource.OpenFunc = resource.Open
+
+// openTemplates opens the templates directory.
+var openTemplates = resource.OpenTemplates
+
+// openSchemas opens the spec schema registry.
+var openSchemas = resource.OpenSchemas
+
+// ids generates IDs for new resources; tests swap in resource.NewSequentialIDs.
+var ids resource.IDGenerator = resource.NewTimeOrderedIDs()
+
+// Run creates the resource in the store
 func Run(ctx context.Context, logger *slog.Logger, cfg Config) (Result, error) {
 	logger.Info("Creating resource",
+		"template", cfg.Template,
+		"vars", cfg.Vars,
+		"namespace", cfg.Namespace,
 		"name", cfg.Name,
+		"kind", cfg.Kind,
 		"description", cfg.Description,
 		"tags", cfg.Tags,
+		"labels", cfg.Labels,
+		"spec_file", cfg.SpecFile,
+		"spec", cfg.Spec,
+		"depends_on", cfg.DependsOn,
+		"ttl", time.Duration(cfg.TTL),
+		"expires_at", cfg.ExpiresAt,
 		"enabled", cfg.Enabled,
 		"dry_run", cfg.DryRun,
 		"force", cfg.Force,
+		"actor", cfg.Actor,
 	)
 
-	result := Result{
-		Success:     true,
-		ResourceID:  resource.ID("res-" + strings.ReplaceAll(string(cfg.Name), " ", "-")),
+	var base resource.Spec
+	if cfg.Template != "" {
+		var err error
+		if cfg, base, err = applyTemplate(cfg); err != nil {
+			return Result{}, err
+		}
+	}
+
+	labels, err := resource.ParseLabels(cfg.Labels)
+	if err != nil {
+		return Result{}, err
+	}
+
+	spec, err := buildSpec(cfg, base)
+	if err != nil {
+		return Result{}, err
+	}
+	schemas, err := openSchemas(cfg.State)
+	if err != nil {
+		return Result{}, err
+	}
+	if err := schemas.Validate(cfg.Kind, spec); err != nil {
+		return Result{}, err
+	}
+
+	res := resource.Resource{
+		Namespace:   cfg.Namespace,
 		Name:        cfg.Name,
+		Kind:        cfg.Kind,
 		Description: cfg.Description,
-		Tags:        cfg.Tags,
+		Status:      resource.StatusPending,
+		Tags:        append([]resource.Tag{}, cfg.Tags...),
+		Labels:      labels,
+		Spec:        spec,
 		Enabled:     cfg.Enabled,
+	}
+	if res.ExpiresAt, err = expiry(cfg, time.Now().UTC()); err != nil {
+		return Result{}, err
+	}
+	if cfg.Enabled {
+		res.Status = resource.StatusActive
+	}
+
+	store, err := openStore(cfg.State)
+	if err != nil {
+		return Result{}, fmt.Errorf("open resource store: %w", err)
+	}
+	if err := resource.CheckNamespace(ctx, store, cfg.Namespace); err != nil {
+		return Result{}, err
+	}
+
+	if res.DependsOn, err = resource.ResolveDependencies(ctx, store, cfg.Namespace, cfg.DependsOn); err != nil {
+		return Result{}, err
+	}
+
+	existing, found, err := findLive(ctx, store, cfg.Namespace, cfg.Name)
+	if err != nil {
+		return Result{}, err
+	}
+	if found {
+		if !cfg.Force {
+			return Result{}, &resource.AlreadyExistsError{ID: existing.ID, Name: existing.Name}
+		}
+		res.ID = existing.ID
+		res.Version = existing.Version
+		// The replacement keeps the status history. Nothing returns to
+		// pending, so the status only changes when enabled asks for active.
+		res.Status, res.Transitions = existing.Status, existing.Transitions
+		if cfg.Enabled && res.Status != resource.StatusActive {
+			if err := res.Transition(resource.StatusActive, cfg.Actor, time.Now().UTC()); err != nil {
+				return Result{}, fmt.Errorf("replace %s: %w", res.ID, err)
+			}
+		}
+	} else {
+		res.ID = ids.NewID()
+	}
+
+	result := Result{
+		Success:     true,
+		ResourceID:  res.ID,
+		Namespace:   res.Namespace,
+		Version:     res.Version + 1,
+		Name:        res.Name,
+		Kind:        res.Kind,
+		Template:    cfg.Template,
+		Description: res.Description,
+		Tags:        res.Tags,
+		Labels:      res.Labels,
+		Spec:        res.Spec,
+		DependsOn:   res.DependsOn,
+		ExpiresAt:   res.ExpiresAt,
+		Enabled:     res.Enabled,
 		DryRun:      cfg.DryRun,
 		Force:       cfg.Force,
-		Message:     "Resource created successfully (noop)",
+		Overwritten: found,
+		Message:     "Resource created successfully",
+	}
+	if found {
+		result.Message = "Resource replaced successfully"
 	}
 
 	if cfg.DryRun {
-		result.Message = "Dry run: would have created resource (noop)"
+		result.Message = "Dry run: would have created resource"
+		if found {
+			result.Message = "Dry run: would have replaced resource"
+		}
+		logger.Info("Resource creation complete", "resource_id", result.ResourceID)
+		return result, nil
 	}
 
-	logger.Info("Resource creation complete", "resource_id", result.ResourceID)
+	if found {
+		res, err = store.Update(ctx, res)
+	} else {
+		res, err = store.Create(ctx, res)
+	}
+	if err != nil {
+		return Result{}, err
+	}
+	result.Version = res.Version
+
+	logger.Info("Resource creation complete", "resource_id", result.ResourceID, "overwritten", result.Overwritten)
 	return result, nil
 }
+
+// expiry returns when a resource created now expires, or nil if it never does
+func expiry(cfg Config, now time.Time) (*resource.ExpiresAt, error) {
+	var at time.Time
+	switch {
+	case cfg.TTL > 0:
+		at = now.Add(time.Duration(cfg.TTL))
+	case cfg.ExpiresAt != "":
+		var err error
+		if at, err = cfg.ExpiresAt.Resolve(now); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, nil
+	}
+	expiresAt := resource.ExpiresAt(at.UTC())
+	return &expiresAt, nil
+}
+
+// findLive returns the resource of namespace ns named name that is not in the
+// trash, if any
+func findLive(ctx context.Context, store resource.Store, ns resource.Namespace, name resource.Name) (resource.Resource, bool, error) {
+	all, err := store.List(ctx)
+	if err != nil {
+		return resource.Resource{}, false, err
+	}
+	for _, res := range resource.InNamespace(all, ns) {
+		if res.Name == name && !res.Deleted() {
+			return res, true, nil
+		}
+	}
+	return resource.Resource{}, false, nil
+}
+
+// applyTemplate renders cfg.Template and fills in the values cfg leaves
+// unset from it. It returns the completed config and the template's spec.
+func applyTemplate(cfg Config) (Config, resource.Spec, error) {
+	templates, err := openTemplates(cfg.State)
+	if err != nil {
+		return Config{}, nil, err
+	}
+	tmpl, err := templates.Load(cfg.Template)
+	if err != nil {
+		return Config{}, nil, err
+	}
+
+	vars := map[string]string{}
+	for _, v := range cfg.Vars {
+		name, value, _ := v.Split() // checked by Validate
+		vars[name] = value
+	}
+	tmpl, err = tmpl.Render(vars)
+	if err != nil {
+		return Config{}, nil, &resource.TemplateError{Template: string(cfg.Template), Err: err}
+	}
+
+	if cfg.Name == "" {
+		cfg.Name = resource.Name(tmpl.Name)
+	}
+	if cfg.Kind == "" {
+		cfg.Kind = resource.Kind(tmpl.Kind)
+	}
+	if !cfg.SetDescription {
+		cfg.Description = resource.Description(tmpl.Description)
+	}
+	if len(cfg.Tags) == 0 {
+		for _, tag := range tmpl.Tags {
+			cfg.Tags = append(cfg.Tags, resource.Tag(tag))
+		}
+	}
+	if !cfg.SetEnabled && tmpl.Enabled != nil {
+		cfg.Enabled = *tmpl.Enabled
+	}
+	// Template labels come first so that those given on the command line win.
+	labels := make([]resource.Label, 0, len(tmpl.Labels)+len(cfg.Labels))
+	for _, key := range slices.Sorted(maps.Keys(tmpl.Labels)) {
+		labels = append(labels, resource.Label(key+"="+tmpl.Labels[key]))
+	}
+	cfg.Labels = append(labels, cfg.Labels...)
+
+	// The rendered values are checked like those given on the command line.
+	if err := cfg.validate(false); err != nil {
+		return Config{}, nil, err
+	}
+	return cfg, tmpl.Spec, nil
+}
+
+// buildSpec reads cfg.SpecFile, if any, in place of the template's spec and
+// applies the --set values over it
+func buildSpec(cfg Config, base resource.Spec) (resource.Spec, error) {
+	if cfg.SpecFile != "" {
+		var err error
+		if base, err = resource.ReadSpecFile(string(cfg.SpecFile)); err != nil {
+			return nil, err
+		}
+	}
+	return base.Apply(cfg.Spec, nil)
+}
@@ -0,0 +1,67 @@
+package create
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// setup points the runner at an in-memory store and predictable IDs.
+func setup(t *testing.T) *resource.MemoryStore {
+	t.Helper()
+	store := resource.NewMemoryStore()
+	prevStore, prevIDs := openStore, ids
+	openStore, ids = resource.OpenMemory(store), resource.NewSequentialIDs(1)
+	t.Cleanup(func() { openStore, ids = prevStore, prevIDs })
+	return store
+}
+
+func run(t *testing.T, cfg Config) (Result, error) {
+	t.Helper()
+	cfg.Namespace = resource.DefaultNamespace
+	cfg.State = resource.StateDir(t.TempDir())
+	return Run(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)), cfg)
+}
+
+func TestRun(t *testing.T) {
+	store := setup(t)
+
+	result, err := run(t, Config{Name: "db", Enabled: true})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if result.ResourceID != "res-000001" || result.Version != 1 || result.Overwritten {
+		t.Errorf("Run = %+v, want a new res-000001 at version 1", result)
+	}
+	res, err := store.Get(context.Background(), result.ResourceID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if res.Status != resource.StatusActive {
+		t.Errorf("status = %s, want %s", res.Status, resource.StatusActive)
+	}
+
+	if _, err := run(t, Config{Name: "db"}); !errors.Is(err, resource.ErrAlreadyExists) {
+		t.Errorf("Run on a taken name: error = %v, want %v", err, resource.ErrAlreadyExists)
+	}
+
+	replaced, err := run(t, Config{Name: "db", Description: "replaced", Force: true})
+	if err != nil {
+		t.Fatalf("Run with Force: %v", err)
+	}
+	if replaced.ResourceID != result.ResourceID || replaced.Version != 2 || !replaced.Overwritten {
+		t.Errorf("Run with Force = %+v, want res-000001 replaced at version 2", replaced)
+	}
+
+	dry, err := run(t, Config{Name: "web", DryRun: true})
+	if err != nil {
+		t.Fatalf("Run with DryRun: %v", err)
+	}
+	if all, _ := store.List(context.Background()); len(all) != 1 {
+		t.Errorf("dry run %s left %d resources, want 1", dry.ResourceID, len(all))
+	}
+}
@@ -0,0 +1,86 @@
+package resource
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Cursor is an opaque pagination token identifying a position in a sorted listing.
+type Cursor string
+
+// ErrInvalidCursor is returned for cursors that cannot be decoded or that were
+// issued for a different sort order.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// cursorPosition records the sort key values of the last resource on a page.
+// Positions rather than offsets keep paging stable when resources are
+// inserted between requests.
+type cursorPosition struct {
+	Sort      string    `json:"s"`
+	ID        ID        `json:"id"`
+	Name      Name      `json:"n,omitempty"`
+	Status    Status    `json:"st,omitempty"`
+	CreatedAt time.Time `json:"c"`
+	TagCount  int       `json:"t,omitempty"`
+}
+
+// NewCursor returns a cursor positioned after last under the given sort keys.
+func NewCursor(keys []SortKey, last Resource) Cursor {
+	data, _ := json.Marshal(cursorPosition{
+		Sort:      sortSpec(keys),
+		ID:        last.ID,
+		Name:      last.Name,
+		Status:    last.Status,
+		CreatedAt: time.Time(last.CreatedAt),
+		TagCount:  len(last.Tags),
+	})
+	return Cursor(base64.RawURLEncoding.EncodeToString(data))
+}
+
+// Seek returns the resources that sort after the cursor. resources must
+// already be ordered by Sort(resources, keys).
+func (c Cursor) Seek(resources []Resource, keys []SortKey) ([]Resource, error) {
+	if c == "" {
+		return resources, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(string(c))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	var pos cursorPosition
+	if err := json.Unmarshal(data, &pos); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	if pos.Sort != sortSpec(keys) {
+		return nil, fmt.Errorf("%w: issued for sort order %q, not %q", ErrInvalidCursor, pos.Sort, sortSpec(keys))
+	}
+
+	last := Resource{
+		ID:        pos.ID,
+		Name:      pos.Name,
+		Status:    pos.Status,
+		CreatedAt: CreatedAt(pos.CreatedAt),
+		Tags:      make([]Tag, pos.TagCount),
+	}
+	compare := Compare(keys)
+	i := slices.IndexFunc(resources, func(res Resource) bool { return compare(res, last) > 0 })
+	if i < 0 {
+		return resources[len(resources):], nil
+	}
+	return resources[i:], nil
+}
+
+// sortSpec returns a canonical representation of the sort keys.
+func sortSpec(keys []SortKey) string {
+	specs := make([]string, len(keys))
+	for i, key := range keys {
+		specs[i] = key.String()
+	}
+	return strings.Join(specs, ",")
+}
@@ -0,0 +1,61 @@
+package resource
+
+import (
+	"maps"
+	"slices"
+	"time"
+)
+
+// FieldChange holds the value of a field before and after a change.
+type FieldChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// Changes maps field names (as they appear in JSON) to their changes.
+type Changes map[string]FieldChange
+
+// Diff returns the fields that differ between before and after.
+// IDs, kinds and creation times are immutable and never reported; neither is
+// the update time, which changes with every update.
+func Diff(before, after Resource) Changes {
+	changes := Changes{}
+	if before.Name != after.Name {
+		changes["name"] = FieldChange{Before: before.Name, After: after.Name}
+	}
+	if before.Description != after.Description {
+		changes["description"] = FieldChange{Before: before.Description, After: after.Description}
+	}
+	if before.Status != after.Status {
+		changes["status"] = FieldChange{Before: before.Status, After: after.Status}
+	}
+	if !slices.Equal(before.Tags, after.Tags) {
+		changes["tags"] = FieldChange{Before: before.Tags, After: after.Tags}
+	}
+	if !maps.Equal(before.Labels, after.Labels) {
+		changes["labels"] = FieldChange{Before: before.Labels, After: after.Labels}
+	}
+	if !slices.Equal(before.DependsOn, after.DependsOn) {
+		changes["depends_on"] = FieldChange{Before: before.DependsOn, After: after.DependsOn}
+	}
+	if !before.Spec.Equal(after.Spec) {
+		changes["spec"] = FieldChange{Before: before.Spec, After: after.Spec}
+	}
+	if before.Enabled != after.Enabled {
+		changes["enabled"] = FieldChange{Before: before.Enabled, After: after.Enabled}
+	}
+	if !equalExpiry(before.ExpiresAt, after.ExpiresAt) {
+		changes["expires_at"] = FieldChange{Before: before.ExpiresAt, After: after.ExpiresAt}
+	}
+	if before.Deleted() != after.Deleted() {
+		changes["deleted_at"] = FieldChange{Before: before.DeletedAt, After: after.DeletedAt}
+	}
+	return changes
+}
+
+func equalExpiry(a, b *ExpiresAt) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return time.Time(*a).Equal(time.Time(*b))
+}
@@ -0,0 +1,27 @@
+package export
+
+import (
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/app/log"
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// Config holds configuration for exporting resources
+type Config struct {
+	Format    resource.Format    // Output encoding
+	Namespace resource.Namespace // Namespace of the resources
+	State     resource.StateDir  // Resource store directory
+	Output    app.FilePath       // Output file path
+	Logging   log.Config
+}
+
+// Validate implements app.Validator
+func (c Config) Validate() error {
+	var v app.ValidationError
+	v.Check("namespace", c.Namespace.Validate())
+	v.Check("format", c.Format.Validate())
+	return v.Err()
+}
+
+func (c Config) OutputFilePath() app.FilePath { return c.Output }
+func (c Config) LoggerConfig() log.Config     { return c.Logging }
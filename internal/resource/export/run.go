@@ -0,0 +1,70 @@
+// Package export implements bulk export of resources
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// Result holds the exported resources. It is written in Format rather than as
+// a JSON envelope.
+type Result struct {
+	Format    resource.Format `json:"format"`
+	Resources []resource.Row  `json:"resources"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (r Result) MarshalJSON() ([]byte, error) {
+	type Alias Result
+	return json.Marshal((Alias)(r))
+}
+
+// Render implements app.Renderer
+func (r Result) Render() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := resource.EncodeRows(&buf, r.Format, r.Resources); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// openStore opens the resource store.
+var openStore resource.OpenFunc = resource.Open
+
+// Run reads every resource that is not in the trash
+func Run(ctx context.Context, logger *slog.Logger, cfg Config) (Result, error) {
+	logger.Info("Exporting resources", "namespace", cfg.Namespace, "format", cfg.Format)
+
+	store, err := openStore(cfg.State)
+	if err != nil {
+		return Result{}, fmt.Errorf("open resource store: %w", err)
+	}
+
+	all, err := store.List(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	all = resource.InNamespace(all, cfg.Namespace)
+
+	// Dependencies come before their dependents so the output imports in order.
+	var live []resource.ID
+	for _, res := range all {
+		if !res.Deleted() {
+			live = append(live, res.ID)
+		}
+	}
+	graph := resource.NewGraph(all)
+	rows := []resource.Row{}
+	for _, id := range graph.CreationOrder(live) {
+		res, _ := graph.Resource(id)
+		rows = append(rows, resource.NewRow(res))
+	}
+
+	logger.Info("Resource export complete", "count", len(rows))
+	return Result{Format: cfg.Format, Resources: rows}, nil
+}
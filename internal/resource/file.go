@@ -0,0 +1,265 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// stateFileName is the name of the file holding resources within a state directory.
+const stateFileName = "resources.json"
+
+// lockFileName is the name of the file locked around every access to the
+// state file, so that separate processes see each other's writes.
+const lockFileName = "resources.lock"
+
+// FileStore is a Store persisted as a single JSON document in a state directory.
+// Every write replaces the document atomically. Reads take a shared and
+// writes an exclusive lock on a lock file next to it, so that concurrent
+// processes do not lose each other's changes.
+type FileStore struct {
+	mu   sync.Mutex
+	path string
+	lock string
+}
+
+var _ Store = (*FileStore)(nil)
+
+// Open returns a FileStore rooted at dir, creating the directory if needed.
+// An empty dir selects DefaultStateDir.
+func Open(dir StateDir) (Store, error) {
+	return OpenFile(dir)
+}
+
+// OpenFile is Open returning the concrete FileStore.
+func OpenFile(dir StateDir) (*FileStore, error) {
+	if dir == "" {
+		dir = DefaultStateDir()
+	}
+	if err := os.MkdirAll(string(dir), 0o700); err != nil {
+		return nil, fmt.Errorf("create state directory: %w", err)
+	}
+	return &FileStore{
+		path: filepath.Join(string(dir), stateFileName),
+		lock: filepath.Join(string(dir), lockFileName),
+	}, nil
+}
+
+// DefaultStateDir returns $XDG_STATE_HOME/mga, falling back to ~/.local/state/mga.
+func DefaultStateDir() StateDir {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return StateDir(filepath.Join(dir, "mga"))
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		return StateDir(filepath.Join(home, ".local", "state", "mga"))
+	}
+	return StateDir(filepath.Join(os.TempDir(), "mga"))
+}
+
+func (f *FileStore) Create(ctx context.Context, res Resource) (out Resource, err error) {
+	err = f.modify(ctx, func(s *state) error {
+		out, err = s.create(res)
+		return err
+	})
+	return out, err
+}
+
+func (f *FileStore) Get(ctx context.Context, id ID) (out Resource, err error) {
+	err = f.view(ctx, func(s *state) error {
+		out, err = s.get(id)
+		return err
+	})
+	return out, err
+}
+
+func (f *FileStore) List(ctx context.Context) (out []Resource, err error) {
+	err = f.view(ctx, func(s *state) error {
+		out = s.list()
+		return nil
+	})
+	return out, err
+}
+
+func (f *FileStore) Update(ctx context.Context, res Resource) (out Resource, err error) {
+	err = f.modify(ctx, func(s *state) error {
+		out, err = s.update(res)
+		return err
+	})
+	return out, err
+}
+
+func (f *FileStore) Delete(ctx context.Context, id ID) error {
+	return f.modify(ctx, func(s *state) error {
+		return s.delete(id)
+	})
+}
+
+func (f *FileStore) History(ctx context.Context, id ID) (out []Revision, err error) {
+	err = f.view(ctx, func(s *state) error {
+		out, err = s.history(id)
+		return err
+	})
+	return out, err
+}
+
+func (f *FileStore) Batch(ctx context.Context, fn func(Store) error) error {
+	return f.modify(ctx, func(s *state) error {
+		return fn(batch{state: s})
+	})
+}
+
+func (f *FileStore) Namespaces(ctx context.Context) (out []NamespaceInfo, err error) {
+	err = f.view(ctx, func(s *state) error {
+		out = s.namespaces()
+		return nil
+	})
+	return out, err
+}
+
+func (f *FileStore) CreateNamespace(ctx context.Context, ns Namespace) (out NamespaceInfo, err error) {
+	err = f.modify(ctx, func(s *state) error {
+		out, err = s.createNamespace(ns)
+		return err
+	})
+	return out, err
+}
+
+func (f *FileStore) DeleteNamespace(ctx context.Context, ns Namespace) error {
+	return f.modify(ctx, func(s *state) error {
+		return s.deleteNamespace(ns)
+	})
+}
+
+// view loads the state and passes it to fn without persisting changes.
+func (f *FileStore) view(ctx context.Context, fn func(*state) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	unlock, err := lockFile(f.lock, false)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	s, err := f.load()
+	if err != nil {
+		return err
+	}
+	return fn(s)
+}
+
+// modify loads the state, applies fn and persists the result if fn succeeds.
+func (f *FileStore) modify(ctx context.Context, fn func(*state) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	unlock, err := lockFile(f.lock, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	s, err := f.load()
+	if err != nil {
+		return err
+	}
+	if err := fn(s); err != nil {
+		return err
+	}
+	return f.save(s)
+}
+
+// document is the on-disk layout of the state file.
+type document struct {
+	Namespaces []NamespaceInfo   `json:"namespaces,omitempty"`
+	Resources  []Resource        `json:"resources"`
+	Revisions  map[ID][]Revision `json:"revisions,omitempty"`
+}
+
+func (f *FileStore) load() (*state, error) {
+	s := newState()
+
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read state file: %w", err)
+	}
+
+	var doc document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse state file %s: %w", f.path, err)
+	}
+	for _, res := range doc.Resources {
+		s.Resources[res.ID] = res
+	}
+	for id, revs := range doc.Revisions {
+		s.Revisions[id] = revs
+	}
+	for _, ns := range doc.Namespaces {
+		s.Namespaces[ns.Name] = ns
+	}
+	// State written before resources were versioned counts one version per
+	// revision, and takes the update time from the latest revision. State
+	// written before namespaces keeps every resource in DefaultNamespace.
+	for id, res := range s.Resources {
+		if res.Namespace == "" {
+			res.Namespace = DefaultNamespace
+		}
+		revs := s.Revisions[id]
+		if res.Version == 0 {
+			res.Version = Version(max(1, len(revs)))
+		}
+		if time.Time(res.UpdatedAt).IsZero() {
+			res.UpdatedAt = UpdatedAt(res.CreatedAt)
+			if len(revs) > 0 {
+				res.UpdatedAt = UpdatedAt(revs[len(revs)-1].At)
+			}
+		}
+		s.Resources[id] = res
+	}
+	return s, nil
+}
+
+func (f *FileStore) save(s *state) error {
+	doc := document{Resources: s.list(), Revisions: s.Revisions}
+	for _, ns := range slices.Sorted(maps.Keys(s.Namespaces)) {
+		doc.Namespaces = append(doc.Namespaces, s.Namespaces[ns])
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), stateFileName+".*")
+	if err != nil {
+		return fmt.Errorf("write state file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write state file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write state file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), f.path); err != nil {
+		return fmt.Errorf("write state file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+// Package get implements fetching a single resource
+package get
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// Result holds the fetched resource record
+type Result struct {
+	resource.Resource
+}
+
+// MarshalJSON implements json.Marshaler
+func (r Result) MarshalJSON() ([]byte, error) {
+	type Alias Result
+	return json.Marshal((Alias)(r))
+}
+
+// openStore opens the resource store.
+var openStore resource.OpenFunc = resource.Open
+
+// Run looks up a resource by ID or name
+func Run(ctx context.Context, logger *slog.Logger, cfg Config) (Result, error) {
+	logger.Info("Getting resource", "namespace", cfg.Namespace, "ref", cfg.Ref)
+
+	store, err := openStore(cfg.State)
+	if err != nil {
+		return Result{}, fmt.Errorf("open resource store: %w", err)
+	}
+
+	res, err := resource.Find(ctx, store, cfg.Namespace, cfg.Ref)
+	if err != nil {
+		return Result{}, err
+	}
+
+	logger.Info("Resource get complete", "resource_id", res.ID)
+	return Result{Resource: res}, nil
+}
@@ -0,0 +1,280 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Dependency errors
+var (
+	ErrCycle         = errors.New("dependency cycle")
+	ErrHasDependents = errors.New("resource has dependents")
+)
+
+// CycleError reports that a change would make a resource depend on itself.
+type CycleError struct {
+	Path []ID // The cycle, starting and ending with the same resource
+}
+
+func (e *CycleError) Error() string {
+	parts := make([]string, len(e.Path))
+	for i, id := range e.Path {
+		parts[i] = string(id)
+	}
+	return fmt.Sprintf("dependency cycle: %s", strings.Join(parts, " -> "))
+}
+func (e *CycleError) Is(target error) bool { return target == ErrCycle }
+
+// ExitCode implements cli.ExitCoder.
+func (e *CycleError) ExitCode() int { return ExitCodeInvalid }
+
+// HasDependentsError reports that a resource cannot be deleted while other
+// resources depend on it: live ones for a move to the trash, any for a
+// permanent delete.
+type HasDependentsError struct {
+	ID         ID
+	Dependents []ID // Resources depending on ID, directly or not
+}
+
+func (e *HasDependentsError) Error() string {
+	return fmt.Sprintf("resource %s has %d dependents; use --cascade to delete them too", e.ID, len(e.Dependents))
+}
+func (e *HasDependentsError) Is(target error) bool { return target == ErrHasDependents }
+
+// ExitCode implements cli.ExitCoder.
+func (e *HasDependentsError) ExitCode() int { return ExitCodeHasDependents }
+
+// Graph is the dependency graph of a set of resources. An edge runs from a
+// resource to each resource in its DependsOn.
+type Graph struct {
+	resources  map[ID]Resource
+	dependents map[ID][]ID
+}
+
+// NewGraph builds the dependency graph of resources.
+func NewGraph(resources []Resource) *Graph {
+	g := &Graph{resources: map[ID]Resource{}, dependents: map[ID][]ID{}}
+	for _, res := range resources {
+		g.resources[res.ID] = res
+	}
+	for _, res := range resources {
+		for _, dep := range res.DependsOn {
+			g.dependents[dep] = append(g.dependents[dep], res.ID)
+		}
+	}
+	for _, ids := range g.dependents {
+		slices.Sort(ids)
+	}
+	return g
+}
+
+// Resource returns the resource with the given ID, if it is in the graph.
+func (g *Graph) Resource(id ID) (Resource, bool) {
+	res, ok := g.resources[id]
+	return res, ok
+}
+
+// Dependencies returns every resource id depends on, directly or not,
+// nearest first.
+func (g *Graph) Dependencies(id ID) []ID {
+	return g.walk(id, func(id ID) []ID { return g.resources[id].DependsOn })
+}
+
+// Dependents returns every resource depending on id, directly or not,
+// nearest first. Resources in the trash are skipped unless includeDeleted.
+func (g *Graph) Dependents(id ID, includeDeleted bool) []ID {
+	return g.walk(id, func(id ID) []ID {
+		var out []ID
+		for _, dep := range g.dependents[id] {
+			if includeDeleted || !g.resources[dep].Deleted() {
+				out = append(out, dep)
+			}
+		}
+		return out
+	})
+}
+
+// walk returns the nodes reachable from start, breadth first, excluding start.
+func (g *Graph) walk(start ID, next func(ID) []ID) []ID {
+	seen := map[ID]bool{start: true}
+	var out []ID
+	queue := []ID{start}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, n := range next(id) {
+			if !seen[n] {
+				seen[n] = true
+				out = append(out, n)
+				queue = append(queue, n)
+			}
+		}
+	}
+	return out
+}
+
+// Cycle returns a dependency path from id back to itself, or nil if there is none.
+func (g *Graph) Cycle(id ID) []ID {
+	visited := map[ID]bool{}
+	var path []ID
+	var visit func(ID) bool
+	visit = func(current ID) bool {
+		path = append(path, current)
+		for _, dep := range g.resources[current].DependsOn {
+			if dep == id {
+				path = append(path, dep)
+				return true
+			}
+			if !visited[dep] {
+				visited[dep] = true
+				if visit(dep) {
+					return true
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		return false
+	}
+	if visit(id) {
+		return path
+	}
+	return nil
+}
+
+// CreationOrder orders ids so that every resource comes after the resources
+// it depends on (a topological order). Otherwise the order of ids is kept.
+func (g *Graph) CreationOrder(ids []ID) []ID {
+	set := map[ID]bool{}
+	for _, id := range ids {
+		set[id] = true
+	}
+
+	// Kahn's algorithm over the subgraph: pending counts unplaced dependencies.
+	pending := map[ID]int{}
+	for _, id := range ids {
+		for _, dep := range g.resources[id].DependsOn {
+			if set[dep] {
+				pending[id]++
+			}
+		}
+	}
+	out := make([]ID, 0, len(ids))
+	placed := map[ID]bool{}
+	for len(out) < len(ids) {
+		progressed := false
+		for _, id := range ids {
+			if placed[id] || pending[id] > 0 {
+				continue
+			}
+			placed[id], progressed = true, true
+			out = append(out, id)
+			for _, dependent := range g.dependents[id] {
+				if set[dependent] {
+					pending[dependent]--
+				}
+			}
+		}
+		if !progressed {
+			break // Only reachable with a cycle, which the store rejects
+		}
+	}
+	return out
+}
+
+// DeletionOrder orders ids so that every resource comes before the resources
+// it depends on: the reverse of CreationOrder.
+func (g *Graph) DeletionOrder(ids []ID) []ID {
+	order := g.CreationOrder(ids)
+	slices.Reverse(order)
+	return order
+}
+
+// Removable splits ids into the resources that can be removed together, in
+// deletion order, and those held back by dependents that are not being
+// removed, mapped to those dependents. Holding back a resource can hold back
+// what it depends on in turn. Resources in the trash count as dependents
+// when includeDeleted is set, as they must for a permanent delete.
+func (g *Graph) Removable(ids []ID, includeDeleted bool) (removable []ID, held map[ID][]ID) {
+	removing := map[ID]bool{}
+	for _, id := range ids {
+		removing[id] = true
+	}
+	held = map[ID][]ID{}
+	for changed := true; changed; {
+		changed = false
+		for _, id := range slices.Sorted(maps.Keys(removing)) {
+			var staying []ID
+			for _, dep := range g.Dependents(id, includeDeleted) {
+				if !removing[dep] {
+					staying = append(staying, dep)
+				}
+			}
+			if len(staying) > 0 {
+				delete(removing, id)
+				held[id] = staying
+				changed = true
+			}
+		}
+	}
+	return g.DeletionOrder(slices.Sorted(maps.Keys(removing))), held
+}
+
+// ResolveDependencies looks up each reference in namespace ns and returns the
+// IDs of the referenced resources without duplicates, in the order given.
+func ResolveDependencies(ctx context.Context, store Store, ns Namespace, refs []Ref) ([]ID, error) {
+	var ids []ID
+	for _, ref := range refs {
+		res, err := Find(ctx, store, ns, ref)
+		if err != nil {
+			return nil, fmt.Errorf("dependency: %w", err)
+		}
+		if !slices.Contains(ids, res.ID) {
+			ids = append(ids, res.ID)
+		}
+	}
+	return ids, nil
+}
+
+// checkDependents rejects removing id while live resources depend on it, or
+// any resources at all when includeDeleted is set.
+func (s *state) checkDependents(id ID, includeDeleted bool) error {
+	if dependents := NewGraph(s.list()).Dependents(id, includeDeleted); len(dependents) > 0 {
+		return &HasDependentsError{ID: id, Dependents: dependents}
+	}
+	return nil
+}
+
+// checkDependencies rejects dependencies of a live resource on itself, on
+// missing or trashed resources, and on anything that leads back to it.
+func (s *state) checkDependencies(res Resource) error {
+	if res.Deleted() {
+		return nil
+	}
+	for _, dep := range res.DependsOn {
+		if dep == res.ID {
+			return &CycleError{Path: []ID{res.ID, res.ID}}
+		}
+		target, ok := s.Resources[dep]
+		if !ok {
+			return fmt.Errorf("dependency of %s: %w", res.ID, &NotFoundError{Ref: Ref(dep)})
+		}
+		if target.Deleted() {
+			return fmt.Errorf("dependency %s of %s: %w; restore it first", dep, res.ID, ErrDeleted)
+		}
+	}
+
+	all := make([]Resource, 0, len(s.Resources)+1)
+	for id, other := range s.Resources {
+		if id != res.ID {
+			all = append(all, other)
+		}
+	}
+	if path := NewGraph(append(all, res)).Cycle(res.ID); path != nil {
+		return &CycleError{Path: path}
+	}
+	return nil
+}
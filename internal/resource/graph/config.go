@@ -0,0 +1,49 @@
+package graph
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/app/log"
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// Format is the encoding of a dependency graph.
+type Format string
+
+// Supported graph formats.
+const (
+	FormatJSON Format = "json"
+	FormatDOT  Format = "dot"
+)
+
+// Validate checks that the format is supported.
+func (f Format) Validate() error {
+	if !slices.Contains([]Format{FormatJSON, FormatDOT}, f) {
+		return fmt.Errorf("unsupported format %q (valid: json, dot)", f)
+	}
+	return nil
+}
+
+// Config holds configuration for showing the dependency graph of a resource
+type Config struct {
+	Ref       resource.Ref       // Resource ID or name
+	Format    Format             // Output encoding
+	Namespace resource.Namespace // Namespace of the resource
+	State     resource.StateDir  // Resource store directory
+	Output    app.FilePath       // Output file path
+	Logging   log.Config
+}
+
+// Validate implements app.Validator
+func (c Config) Validate() error {
+	var v app.ValidationError
+	v.Check("namespace", c.Namespace.Validate())
+	v.Check("id|name", c.Ref.Validate())
+	v.Check("format", c.Format.Validate())
+	return v.Err()
+}
+
+func (c Config) OutputFilePath() app.FilePath { return c.Output }
+func (c Config) LoggerConfig() log.Config     { return c.Logging }
@@ -0,0 +1,133 @@
+// Package graph implements showing the dependency graph of a resource
+package graph
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// Node is a resource in the graph.
+type Node struct {
+	ID      resource.ID     `json:"id"`
+	Name    resource.Name   `json:"name"`
+	Kind    resource.Kind   `json:"kind,omitempty"`
+	Status  resource.Status `json:"status"`
+	Deleted bool            `json:"deleted,omitempty"`
+}
+
+// Edge runs from a resource to a resource it depends on.
+type Edge struct {
+	From resource.ID `json:"from"`
+	To   resource.ID `json:"to"`
+}
+
+// Result holds the dependency graph around a resource. Nodes are the resource,
+// everything it depends on and everything depending on it, in creation order.
+type Result struct {
+	ResourceID   resource.ID   `json:"resource_id"`
+	Name         resource.Name `json:"name"`
+	Format       Format        `json:"format"`
+	Nodes        []Node        `json:"nodes"`
+	Edges        []Edge        `json:"edges"`
+	Dependencies []resource.ID `json:"dependencies"`
+	Dependents   []resource.ID `json:"dependents"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (r Result) MarshalJSON() ([]byte, error) {
+	type Alias Result
+	return json.Marshal((Alias)(r))
+}
+
+// Render implements app.Renderer
+func (r Result) Render() ([]byte, error) {
+	if r.Format != FormatDOT {
+		return json.MarshalIndent(r, "", "  ")
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "digraph %q {\n", r.Name)
+	for _, node := range r.Nodes {
+		var attrs string
+		switch {
+		case node.ID == r.ResourceID:
+			attrs = ", style=bold"
+		case node.Deleted:
+			attrs = ", style=dashed"
+		}
+		fmt.Fprintf(&buf, "  %q [label=%q%s];\n", node.ID, node.Name, attrs)
+	}
+	for _, edge := range r.Edges {
+		fmt.Fprintf(&buf, "  %q -> %q;\n", edge.From, edge.To)
+	}
+	buf.WriteString("}\n")
+	return buf.Bytes(), nil
+}
+
+// openStore opens the resource store.
+var openStore resource.OpenFunc = resource.Open
+
+// Run collects the resources a resource depends on and those depending on it
+func Run(ctx context.Context, logger *slog.Logger, cfg Config) (Result, error) {
+	logger.Info("Reading resource graph", "namespace", cfg.Namespace, "ref", cfg.Ref, "format", cfg.Format)
+
+	store, err := openStore(cfg.State)
+	if err != nil {
+		return Result{}, fmt.Errorf("open resource store: %w", err)
+	}
+
+	res, err := resource.Find(ctx, store, cfg.Namespace, cfg.Ref)
+	if err != nil {
+		return Result{}, err
+	}
+	all, err := store.List(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+
+	g := resource.NewGraph(all)
+	result := Result{
+		ResourceID:   res.ID,
+		Name:         res.Name,
+		Format:       cfg.Format,
+		Nodes:        []Node{},
+		Edges:        []Edge{},
+		Dependencies: g.Dependencies(res.ID),
+		Dependents:   g.Dependents(res.ID, true),
+	}
+	if result.Dependencies == nil {
+		result.Dependencies = []resource.ID{}
+	}
+	if result.Dependents == nil {
+		result.Dependents = []resource.ID{}
+	}
+
+	ids := append(append([]resource.ID{res.ID}, result.Dependencies...), result.Dependents...)
+	inGraph := map[resource.ID]bool{}
+	for _, id := range ids {
+		inGraph[id] = true
+	}
+	for _, id := range g.CreationOrder(ids) {
+		node, _ := g.Resource(id)
+		result.Nodes = append(result.Nodes, Node{
+			ID:      node.ID,
+			Name:    node.Name,
+			Kind:    node.Kind,
+			Status:  node.Status,
+			Deleted: node.Deleted(),
+		})
+		for _, dep := range node.DependsOn {
+			if inGraph[dep] {
+				result.Edges = append(result.Edges, Edge{From: node.ID, To: dep})
+			}
+		}
+	}
+
+	logger.Info("Resource graph complete", "resource_id", res.ID, "nodes", len(result.Nodes), "edges", len(result.Edges))
+	return result, nil
+}
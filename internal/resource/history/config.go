@@ -0,0 +1,27 @@
+package history
+
+import (
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/app/log"
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// Config holds configuration for listing resource revisions
+type Config struct {
+	Ref       resource.Ref       // Resource ID or name
+	Namespace resource.Namespace // Namespace of the resource
+	State     resource.StateDir  // Resource store directory
+	Output    app.FilePath       // Output file path
+	Logging   log.Config
+}
+
+// Validate implements app.Validator
+func (c Config) Validate() error {
+	var v app.ValidationError
+	v.Check("namespace", c.Namespace.Validate())
+	v.Check("id|name", c.Ref.Validate())
+	return v.Err()
+}
+
+func (c Config) OutputFilePath() app.FilePath { return c.Output }
+func (c Config) LoggerConfig() log.Config     { return c.Logging }
@@ -0,0 +1,61 @@
+// Package history implements listing the revisions of a resource
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// Result holds the revisions of a resource
+type Result struct {
+	ResourceID resource.ID         `json:"resource_id"`
+	Name       resource.Name       `json:"name"`
+	Version    resource.Version    `json:"version"`
+	Revisions  []resource.Revision `json:"revisions"`
+	Total      int                 `json:"total"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (r Result) MarshalJSON() ([]byte, error) {
+	type Alias Result
+	return json.Marshal((Alias)(r))
+}
+
+// openStore opens the resource store.
+var openStore resource.OpenFunc = resource.Open
+
+// Run lists every revision of a resource, oldest first
+func Run(ctx context.Context, logger *slog.Logger, cfg Config) (Result, error) {
+	logger.Info("Listing resource history", "namespace", cfg.Namespace, "ref", cfg.Ref)
+
+	store, err := openStore(cfg.State)
+	if err != nil {
+		return Result{}, fmt.Errorf("open resource store: %w", err)
+	}
+
+	res, err := resource.Find(ctx, store, cfg.Namespace, cfg.Ref)
+	if err != nil {
+		return Result{}, err
+	}
+
+	revisions, err := store.History(ctx, res.ID)
+	if err != nil {
+		return Result{}, err
+	}
+	if revisions == nil {
+		revisions = []resource.Revision{}
+	}
+
+	logger.Info("Resource history complete", "resource_id", res.ID, "total", len(revisions))
+	return Result{
+		ResourceID: res.ID,
+		Name:       res.Name,
+		Version:    res.Version,
+		Revisions:  revisions,
+		Total:      len(revisions),
+	}, nil
+}
@@ -0,0 +1,112 @@
+package resource
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// IDPrefix starts every generated resource ID.
+const IDPrefix = "res-"
+
+// IDGenerator produces new resource IDs.
+type IDGenerator interface {
+	NewID() ID
+}
+
+// crockford is the lowercase Crockford base32 alphabet used by ULIDs.
+const crockford = "0123456789abcdefghjkmnpqrstvwxyz"
+
+// TimeOrderedIDs generates ULID-style IDs: a 48-bit millisecond timestamp
+// followed by 80 random bits, encoded in Crockford base32. IDs sort by
+// creation time, and IDs generated within the same millisecond are
+// monotonically increasing.
+type TimeOrderedIDs struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	lastMS  uint64
+	entropy [10]byte
+}
+
+var _ IDGenerator = (*TimeOrderedIDs)(nil)
+
+// NewTimeOrderedIDs returns a TimeOrderedIDs using the system clock.
+func NewTimeOrderedIDs() *TimeOrderedIDs {
+	return &TimeOrderedIDs{now: time.Now}
+}
+
+func (g *TimeOrderedIDs) NewID() ID {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ms := uint64(g.now().UnixMilli())
+	if ms > g.lastMS || !increment(&g.entropy) {
+		// A new millisecond, or the entropy overflowed: start from fresh random bits.
+		if ms <= g.lastMS {
+			ms = g.lastMS + 1
+		}
+		_, _ = rand.Read(g.entropy[:])
+	}
+	g.lastMS = ms
+
+	var raw [16]byte
+	for i := range 6 {
+		raw[i] = byte(ms >> (40 - 8*i))
+	}
+	copy(raw[6:], g.entropy[:])
+
+	return ID(IDPrefix + encodeCrockford(raw))
+}
+
+// increment adds one to b as a big-endian integer, reporting false on overflow.
+func increment(b *[10]byte) bool {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// encodeCrockford encodes 128 bits as 26 base32 characters, most significant first.
+func encodeCrockford(raw [16]byte) string {
+	var out [26]byte
+	// 26 characters carry 130 bits; the leading two are always zero.
+	var bitBuf uint32
+	bits := 2
+	pos := 0
+	for _, b := range raw {
+		bitBuf = bitBuf<<8 | uint32(b)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			out[pos] = crockford[(bitBuf>>bits)&0x1f]
+			pos++
+		}
+	}
+	return string(out[:])
+}
+
+// SequentialIDs generates predictable IDs (res-000001, res-000002, ...) for tests.
+type SequentialIDs struct {
+	mu   sync.Mutex
+	next int
+}
+
+var _ IDGenerator = (*SequentialIDs)(nil)
+
+// NewSequentialIDs returns a SequentialIDs whose first ID is numbered start.
+func NewSequentialIDs(start int) *SequentialIDs {
+	return &SequentialIDs{next: start}
+}
+
+func (g *SequentialIDs) NewID() ID {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	id := ID(fmt.Sprintf("%s%06d", IDPrefix, g.next))
+	g.next++
+	return id
+}
@@ -0,0 +1,42 @@
+package resource
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSequentialIDs(t *testing.T) {
+	ids := NewSequentialIDs(1)
+	got := []ID{ids.NewID(), ids.NewID(), ids.NewID()}
+	want := []ID{"res-000001", "res-000002", "res-000003"}
+	if !slices.Equal(got, want) {
+		t.Errorf("NewID() = %v, want %v", got, want)
+	}
+}
+
+func TestTimeOrderedIDs(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	ids := &TimeOrderedIDs{now: func() time.Time { return now }}
+
+	// IDs of one millisecond increase monotonically; a later millisecond
+	// sorts after all of them.
+	var got []ID
+	for range 100 {
+		got = append(got, ids.NewID())
+	}
+	now = now.Add(time.Millisecond)
+	got = append(got, ids.NewID())
+
+	if !slices.IsSorted(got) {
+		t.Errorf("IDs are not in creation order: %v", got)
+	}
+	if len(slices.Compact(slices.Clone(got))) != len(got) {
+		t.Errorf("IDs are not unique: %v", got)
+	}
+	for _, id := range got {
+		if len(id) != len(IDPrefix)+26 || id[:len(IDPrefix)] != IDPrefix {
+			t.Errorf("ID %q is not %s followed by 26 characters", id, IDPrefix)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package importer
+
+import (
+	"errors"
+
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/app/log"
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// Stdin is the File value that reads rows from standard input.
+const Stdin app.FilePath = "-"
+
+// Config holds configuration for importing resources
+type Config struct {
+	File      app.FilePath       // File to read rows from, or "-" for stdin
+	Format    resource.Format    // Input encoding; inferred from File when empty
+	Upsert    bool               // Update existing resources instead of failing their rows
+	BatchSize int                // Number of rows committed per store write
+	DryRun    bool               // Dry run mode
+	Actor     resource.Actor     // Who is making the change
+	Namespace resource.Namespace // Namespace of the resources
+	State     resource.StateDir  // Resource store directory
+	Output    app.FilePath       // Output file path
+	Logging   log.Config
+}
+
+// Validate implements app.Validator
+func (c Config) Validate() error {
+	var v app.ValidationError
+	v.Check("namespace", c.Namespace.Validate())
+	if c.File == "" {
+		v.Check("file", errors.New("is required; use - to read from stdin"))
+	}
+	if c.Format != "" {
+		v.Check("format", c.Format.Validate())
+	}
+	if c.BatchSize < 1 {
+		v.Check("batch_size", errors.New("must be at least 1"))
+	}
+	return v.Err()
+}
+
+// format returns the configured format, falling back to one inferred from File.
+func (c Config) format() resource.Format {
+	if c.Format != "" {
+		return c.Format
+	}
+	return resource.FormatForPath(string(c.File))
+}
+
+func (c Config) OutputFilePath() app.FilePath { return c.Output }
+func (c Config) LoggerConfig() log.Config     { return c.Logging }
@@ -0,0 +1,342 @@
+// Package importer implements bulk import of resources
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// Action is what happened, or would happen, to a single row.
+type Action string
+
+// Row actions.
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionSkip   Action = "unchanged"
+	ActionFail   Action = "fail"
+)
+
+// RowResult reports the outcome of a single input row.
+type RowResult struct {
+	Line       int              `json:"line"`
+	Name       resource.Name    `json:"name,omitempty"`
+	ResourceID resource.ID      `json:"resource_id,omitempty"`
+	Version    resource.Version `json:"version,omitempty"` // Version after the import; the current version in a dry run
+	Action     Action           `json:"action"`
+	Error      string           `json:"error,omitempty"`
+}
+
+// Result holds the result of an import
+type Result struct {
+	Success bool             `json:"success"`
+	Format  resource.Format  `json:"format"`
+	Total   int              `json:"total"`
+	Created int              `json:"created"`
+	Updated int              `json:"updated"`
+	Skipped int              `json:"unchanged"`
+	Failed  int              `json:"failed"`
+	Rows    []RowResult      `json:"rows"`
+	DryRun  bool             `json:"dry_run"`
+	Message resource.Message `json:"message"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (r Result) MarshalJSON() ([]byte, error) {
+	type Alias Result
+	return json.Marshal((Alias)(r))
+}
+
+// openStore opens the resource store.
+var openStore resource.OpenFunc = resource.Open
+
+// openSchemas opens the spec schema registry.
+var openSchemas = resource.OpenSchemas
+
+// ids generates IDs for new resources; tests swap in resource.NewSequentialIDs.
+var ids resource.IDGenerator = resource.NewTimeOrderedIDs()
+
+// stdin is read when cfg.File is Stdin.
+var stdin io.Reader = os.Stdin
+
+// Run reads rows from cfg.File, validates all of them and then commits the
+// valid ones in batches. A bad row is reported in the result and does not
+// stop the others.
+func Run(ctx context.Context, logger *slog.Logger, cfg Config) (Result, error) {
+	format := cfg.format()
+	logger.Info("Importing resources",
+		"file", cfg.File,
+		"namespace", cfg.Namespace,
+		"format", format,
+		"upsert", cfg.Upsert,
+		"batch_size", cfg.BatchSize,
+		"dry_run", cfg.DryRun,
+	)
+
+	decoded, err := decode(cfg.File, format)
+	if err != nil {
+		return Result{}, err
+	}
+
+	store, err := openStore(cfg.State)
+	if err != nil {
+		return Result{}, fmt.Errorf("open resource store: %w", err)
+	}
+	if err := resource.CheckNamespace(ctx, store, cfg.Namespace); err != nil {
+		return Result{}, err
+	}
+	existing, err := store.List(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+
+	schemas, err := openSchemas(cfg.State)
+	if err != nil {
+		return Result{}, err
+	}
+
+	plans := plan(decoded, resource.InNamespace(existing, cfg.Namespace), schemas, cfg)
+	if !cfg.DryRun {
+		if err := commit(ctx, store, plans, cfg.BatchSize); err != nil {
+			return Result{}, err
+		}
+	}
+
+	result := Result{Format: format, Total: len(plans), Rows: make([]RowResult, len(plans)), DryRun: cfg.DryRun}
+	for i, p := range plans {
+		row := RowResult{Line: p.line, Name: p.name, ResourceID: p.res.ID, Version: p.res.Version, Action: p.action}
+		if p.err != nil {
+			row.Action, row.ResourceID, row.Version, row.Error = ActionFail, "", 0, p.err.Error()
+		}
+		switch row.Action {
+		case ActionCreate:
+			result.Created++
+		case ActionUpdate:
+			result.Updated++
+		case ActionSkip:
+			result.Skipped++
+		case ActionFail:
+			result.Failed++
+		}
+		result.Rows[i] = row
+	}
+	result.Success = result.Failed == 0
+	result.Message = resource.Message(fmt.Sprintf("Imported %d of %d rows", result.Created+result.Updated, result.Total))
+	if cfg.DryRun {
+		result.Message = resource.Message(fmt.Sprintf("Dry run: would have imported %d of %d rows", result.Created+result.Updated, result.Total))
+	}
+
+	logger.Info("Resource import complete",
+		"created", result.Created,
+		"updated", result.Updated,
+		"unchanged", result.Skipped,
+		"failed", result.Failed,
+	)
+	return result, nil
+}
+
+// decode reads every row from file, or from stdin when file is Stdin.
+func decode(file app.FilePath, format resource.Format) ([]resource.DecodedRow, error) {
+	r := stdin
+	if file != Stdin {
+		f, err := os.Open(string(file))
+		if err != nil {
+			return nil, fmt.Errorf("open import file: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+	return resource.DecodeRows(r, format)
+}
+
+// rowPlan is the change planned for one input row.
+type rowPlan struct {
+	line   int
+	name   resource.Name
+	action Action
+	res    resource.Resource
+	err    error
+}
+
+// plan validates every row and decides whether it creates or updates a
+// resource of namespace cfg.Namespace, without touching the store. existing
+// holds the resources of that namespace.
+func plan(decoded []resource.DecodedRow, existing []resource.Resource, schemas *resource.Schemas, cfg Config) []rowPlan {
+	now := time.Now().UTC()
+	byID := map[resource.ID]resource.Resource{}
+	byName := map[resource.Name]resource.Resource{}
+	for _, res := range existing {
+		byID[res.ID] = res
+		if !res.Deleted() {
+			byName[res.Name] = res
+		}
+	}
+
+	// Lines of earlier rows claiming each ID and name, to reject duplicates in the input.
+	seenIDs := map[resource.ID]int{}
+	seenNames := map[resource.Name]int{}
+
+	plans := make([]rowPlan, len(decoded))
+	for i, d := range decoded {
+		p := &plans[i]
+		p.line, p.name = d.Line, d.Row.Name
+		if p.err = d.Err; p.err != nil {
+			continue
+		}
+		if p.err = validateRow(d.Row, schemas); p.err != nil {
+			continue
+		}
+		if line, ok := seenNames[d.Row.Name]; ok {
+			p.err = fmt.Errorf("name %q already used on line %d", d.Row.Name, line)
+			continue
+		}
+		if line, ok := seenIDs[d.Row.ID]; ok && d.Row.ID != "" {
+			p.err = fmt.Errorf("id %q already used on line %d", d.Row.ID, line)
+			continue
+		}
+		seenNames[d.Row.Name] = d.Line
+		if d.Row.ID != "" {
+			seenIDs[d.Row.ID] = d.Line
+		}
+
+		target, found := byID[d.Row.ID]
+		if !found || d.Row.ID == "" {
+			target, found = byName[d.Row.Name]
+		}
+		switch {
+		case found && !cfg.Upsert:
+			p.err = &resource.AlreadyExistsError{ID: target.ID, Name: target.Name}
+		case found && target.Deleted():
+			p.err = fmt.Errorf("%w: restore %s before importing over it", resource.ErrDeleted, target.ID)
+		case found && d.Row.ID != "" && d.Row.ID != target.ID:
+			p.err = fmt.Errorf("name %q belongs to %s, not %s", d.Row.Name, target.ID, d.Row.ID)
+		case found && d.Row.Version != 0 && d.Row.Version != target.Version:
+			p.err = resource.CheckVersion(target, d.Row.Version)
+		case found && d.Row.Kind != target.Kind:
+			p.err = fmt.Errorf("kind of %s cannot change from %q to %q", target.ID, target.Kind, d.Row.Kind)
+		case found:
+			if other, ok := byName[d.Row.Name]; ok && other.ID != target.ID {
+				p.err = &resource.AlreadyExistsError{ID: other.ID, Name: other.Name}
+				continue
+			}
+			p.action, p.res = ActionUpdate, apply(target, d.Row)
+			// An existing resource keeps its status unless the row names
+			// another, which the lifecycle must allow.
+			if d.Row.Status != "" && d.Row.Status != target.Status {
+				if err := p.res.Transition(d.Row.Status, cfg.Actor, now); err != nil {
+					p.err = fmt.Errorf("status: %w", err)
+					continue
+				}
+			}
+			if len(resource.Diff(target, p.res)) == 0 {
+				p.action = ActionSkip
+			}
+		default:
+			res := apply(resource.Resource{ID: d.Row.ID, Namespace: cfg.Namespace}, d.Row)
+			res.Status = d.Row.Status
+			if res.Status == "" {
+				res.Status = resource.StatusPending
+				if d.Row.Enabled {
+					res.Status = resource.StatusActive
+				}
+			}
+			if res.ID == "" {
+				res.ID = ids.NewID()
+			}
+			if d.Row.CreatedAt != nil {
+				res.CreatedAt = resource.CreatedAt(*d.Row.CreatedAt)
+			}
+			p.action, p.res = ActionCreate, res
+		}
+	}
+	return plans
+}
+
+// validateRow checks a row the way create validates its flags, including the
+// spec against the schema of its kind.
+func validateRow(row resource.Row, schemas *resource.Schemas) error {
+	var problems []string
+	check := func(field string, err error) {
+		if err != nil {
+			problems = append(problems, field+": "+err.Error())
+		}
+	}
+	check("name", row.Name.Validate())
+	if err := row.Kind.Validate(); err != nil {
+		check("kind", err)
+	} else {
+		check("spec", schemas.Validate(row.Kind, row.Spec))
+	}
+	check("description", row.Description.Validate())
+	for i, tag := range row.Tags {
+		check(fmt.Sprintf("tags[%d]", i), tag.Validate())
+	}
+	for key, value := range row.Labels {
+		check("labels", resource.ValidateLabelKey(key))
+		check("labels."+key, resource.ValidateLabelValue(value))
+	}
+	if row.Status != "" {
+		check("status", row.Status.Validate())
+	}
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// apply copies the row's fields other than the status onto res.
+func apply(res resource.Resource, row resource.Row) resource.Resource {
+	res.Name = row.Name
+	res.Kind = row.Kind
+	res.Description = row.Description
+	res.Tags = row.Tags
+	res.Labels = row.Labels
+	res.Spec = row.Spec
+	res.DependsOn = row.DependsOn
+	res.Enabled = row.Enabled
+	res.ExpiresAt = (*resource.ExpiresAt)(row.ExpiresAt)
+	return res
+}
+
+// commit writes the planned changes, batchSize rows per store batch. A row
+// rejected by the store is marked failed; the rest of its batch still commits.
+func commit(ctx context.Context, store resource.Store, plans []rowPlan, batchSize int) error {
+	for start := 0; start < len(plans); start += batchSize {
+		chunk := plans[start:min(start+batchSize, len(plans))]
+		err := store.Batch(ctx, func(tx resource.Store) error {
+			for i := range chunk {
+				p := &chunk[i]
+				if p.err != nil || p.action == ActionSkip {
+					continue
+				}
+				var res resource.Resource
+				if p.action == ActionCreate {
+					res, p.err = tx.Create(ctx, p.res)
+				} else {
+					res, p.err = tx.Update(ctx, p.res)
+				}
+				if p.err == nil {
+					p.res = res
+				}
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("commit rows %d-%d: %w", start+1, start+len(chunk), err)
+		}
+	}
+	return nil
+}
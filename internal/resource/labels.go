@@ -0,0 +1,113 @@
+package resource
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Label is a key=value pair as given on the command line.
+type Label string
+
+// LabelKey is the key of a label.
+type LabelKey string
+
+// Validate checks the key with ValidateLabelKey.
+func (k LabelKey) Validate() error { return ValidateLabelKey(string(k)) }
+
+// Labels holds structured key/value metadata on a resource.
+type Labels map[string]string
+
+// MaxLabelLength limits label key names and values.
+const MaxLabelLength = 63
+
+var (
+	// labelNamePattern matches label key names and non-empty values.
+	labelNamePattern = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`)
+	// labelPrefixPattern matches the optional DNS subdomain prefix of a key.
+	labelPrefixPattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`)
+)
+
+// Split returns the key and value of a key=value label.
+func (l Label) Split() (string, string, error) {
+	key, value, ok := strings.Cut(string(l), "=")
+	if !ok {
+		return "", "", fmt.Errorf("label %q must have the form key=value", l)
+	}
+	return key, value, nil
+}
+
+// Validate checks that the label is key=value with a valid key and value.
+func (l Label) Validate() error {
+	key, value, err := l.Split()
+	if err != nil {
+		return err
+	}
+	if err := ValidateLabelKey(key); err != nil {
+		return err
+	}
+	return ValidateLabelValue(value)
+}
+
+// ValidateLabelKey checks a label key: an optional DNS subdomain prefix and "/",
+// then a name of at most 63 letters, digits, '-', '_' or '.', starting and ending
+// with a letter or digit.
+func ValidateLabelKey(key string) error {
+	prefix, name, hasPrefix := strings.Cut(key, "/")
+	if !hasPrefix {
+		prefix, name = "", key
+	}
+	switch {
+	case name == "":
+		return errors.New("label key must not be empty")
+	case hasPrefix && (len(prefix) > 253 || !labelPrefixPattern.MatchString(prefix)):
+		return fmt.Errorf("label key prefix %q must be a DNS subdomain", prefix)
+	case len(name) > MaxLabelLength:
+		return fmt.Errorf("label key %q must be at most %d characters", key, MaxLabelLength)
+	case !labelNamePattern.MatchString(name):
+		return fmt.Errorf("label key %q may only contain letters, digits, '-', '_' and '.', and must start and end with a letter or digit", key)
+	}
+	return nil
+}
+
+// ValidateLabelValue checks a label value: empty, or at most 63 letters, digits,
+// '-', '_' or '.', starting and ending with a letter or digit.
+func ValidateLabelValue(value string) error {
+	switch {
+	case value == "":
+		return nil
+	case len(value) > MaxLabelLength:
+		return fmt.Errorf("label value %q must be at most %d characters", value, MaxLabelLength)
+	case !labelNamePattern.MatchString(value):
+		return fmt.Errorf("label value %q may only contain letters, digits, '-', '_' and '.', and must start and end with a letter or digit", value)
+	}
+	return nil
+}
+
+// ParseLabels converts key=value labels into Labels. Later labels override earlier ones.
+func ParseLabels(labels []Label) (Labels, error) {
+	if len(labels) == 0 {
+		return nil, nil
+	}
+	out := Labels{}
+	for _, l := range labels {
+		key, value, err := l.Split()
+		if err != nil {
+			return nil, err
+		}
+		out[key] = value
+	}
+	return out, nil
+}
+
+// String renders the labels as sorted key=value pairs.
+func (l Labels) String() string {
+	pairs := make([]string, 0, len(l))
+	for _, key := range slices.Sorted(maps.Keys(l)) {
+		pairs = append(pairs, key+"="+l[key])
+	}
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,81 @@
+package resource
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Actor identifies who performed a change.
+type Actor string
+
+// Transition records a single status change.
+type Transition struct {
+	From  Status    `json:"from"`
+	To    Status    `json:"to"`
+	Actor Actor     `json:"actor,omitempty"`
+	At    time.Time `json:"at"`
+}
+
+// transitions is the lifecycle graph: the statuses reachable from each status.
+// Nothing returns to pending once it has left it.
+var transitions = map[Status][]Status{
+	StatusPending:  {StatusActive, StatusInactive},
+	StatusActive:   {StatusInactive},
+	StatusInactive: {StatusActive},
+}
+
+// Statuses returns all known statuses.
+func Statuses() []Status {
+	return []Status{StatusActive, StatusInactive, StatusPending}
+}
+
+// Validate checks that the status is one of the known statuses.
+func (s Status) Validate() error {
+	if !slices.Contains(Statuses(), s) {
+		return fmt.Errorf("unknown status %q (valid: %s)", s, joinStatuses(Statuses()))
+	}
+	return nil
+}
+
+// Next returns the statuses that s may transition to.
+func (s Status) Next() []Status {
+	return slices.Clone(transitions[s])
+}
+
+// CanTransition reports an error if moving from one status to another is not allowed.
+func CanTransition(from, to Status) error {
+	if err := to.Validate(); err != nil {
+		return err
+	}
+	if from == to {
+		return fmt.Errorf("resource is already %s", to)
+	}
+	if !slices.Contains(transitions[from], to) {
+		next := "none"
+		if allowed := transitions[from]; len(allowed) > 0 {
+			next = joinStatuses(allowed)
+		}
+		return fmt.Errorf("cannot transition from %s to %s (allowed: %s)", from, to, next)
+	}
+	return nil
+}
+
+// Transition moves the resource to a new status and appends the change to its history.
+func (r *Resource) Transition(to Status, actor Actor, at time.Time) error {
+	if err := CanTransition(r.Status, to); err != nil {
+		return err
+	}
+	r.Transitions = append(r.Transitions, Transition{From: r.Status, To: to, Actor: actor, At: at})
+	r.Status = to
+	return nil
+}
+
+func joinStatuses(statuses []Status) string {
+	names := make([]string, len(statuses))
+	for i, s := range statuses {
+		names[i] = string(s)
+	}
+	return strings.Join(names, ", ")
+}
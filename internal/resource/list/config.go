@@ -8,15 +8,22 @@ import (
 
 // Config holds configuration for listing resources
 type Config struct {
-	IncludePatterns resource.Pattern   // Patterns to include
-	ExcludePatterns resource.Pattern   // Patterns to exclude
-	Statuses        []resource.Status  // Filter by statuses
-	Limit           resource.Limit     // Maximum number of results
-	Offset          resource.Offset    // Offset for pagination
-	SortBy          resource.SortField // Sort field
-	Ascending       bool               // Sort direction
-	Output          app.FilePath       // Output file path
-	Logging         log.Config
+	Filter                       // Which resources to list
+	Limit     resource.Limit     // Maximum number of results
+	Offset    resource.Offset    // Offset for pagination
+	Cursor    resource.Cursor    // Cursor for pagination (from a previous next_cursor)
+	SortBy    resource.SortField // Sort field
+	Ascending bool               // Sort direction
+	State     resource.StateDir  // Resource store directory
+	Output    app.FilePath       // Output file path
+	Logging   log.Config
+}
+
+// Validate implements app.Validator
+func (c Config) Validate() error {
+	var v app.ValidationError
+	c.Filter.Check(&v)
+	return v.Err()
 }
 
 func (c Config) OutputFilePath() app.FilePath { return c.Output }
@@ -0,0 +1,114 @@
+package list
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// Filter selects resources. It is shared by the commands that work on a
+// filtered set of resources, such as list and stats.
+type Filter struct {
+	Namespace       resource.Namespace // Namespace of the resources
+	AllNamespaces   bool               // Select resources of every namespace instead
+	IncludePatterns resource.Pattern   // Patterns to include
+	ExcludePatterns resource.Pattern   // Patterns to exclude
+	Statuses        []resource.Status  // Filter by statuses
+	Selector        resource.Selector  // Label selector
+	Where           resource.Where     // Filter expression
+	CreatedAfter    resource.TimeSpec  // Only resources created after this time
+	CreatedBefore   resource.TimeSpec  // Only resources created before this time
+	UpdatedSince    resource.TimeSpec  // Only resources updated at or after this time
+	IncludeDeleted  bool               // Include resources in the trash
+}
+
+// Check records the filter's violations in v.
+func (f Filter) Check(v *app.ValidationError) {
+	if !f.AllNamespaces {
+		v.Check("namespace", f.Namespace.Validate())
+	}
+	for i, status := range f.Statuses {
+		v.Check(fmt.Sprintf("status[%d]", i), status.Validate())
+	}
+	if _, err := f.Selector.Parse(); err != nil {
+		v.Check("selector", err)
+	}
+	if _, err := f.Where.Parse(time.Now()); err != nil {
+		v.Check("where", err)
+	}
+	v.Check("created-after", f.CreatedAfter.Validate())
+	v.Check("created-before", f.CreatedBefore.Validate())
+	v.Check("updated-since", f.UpdatedSince.Validate())
+}
+
+// Matcher is a Filter compiled for matching. Relative times are resolved
+// once, so every resource is matched against the same bounds.
+type Matcher struct {
+	CreatedAfter  *time.Time // Resolved bounds; nil when open
+	CreatedBefore *time.Time
+	UpdatedSince  *time.Time
+
+	namespace      resource.Namespace // Empty for every namespace
+	names          resource.NameFilter
+	statuses       []resource.Status
+	selector       resource.LabelSelector
+	where          *resource.WhereFilter
+	includeDeleted bool
+}
+
+// Matcher compiles the filter, resolving relative times against now.
+func (f Filter) Matcher(now time.Time) (*Matcher, error) {
+	m := &Matcher{namespace: f.Namespace, statuses: f.Statuses, includeDeleted: f.IncludeDeleted}
+	if f.AllNamespaces {
+		m.namespace = ""
+	}
+
+	var err error
+	if m.names, err = resource.NewNameFilter(f.IncludePatterns, f.ExcludePatterns); err != nil {
+		return nil, err
+	}
+	if m.selector, err = f.Selector.Parse(); err != nil {
+		return nil, err
+	}
+	if m.where, err = f.Where.Parse(now); err != nil {
+		return nil, err
+	}
+	for _, bound := range []struct {
+		spec resource.TimeSpec
+		dst  **time.Time
+	}{
+		{f.CreatedAfter, &m.CreatedAfter},
+		{f.CreatedBefore, &m.CreatedBefore},
+		{f.UpdatedSince, &m.UpdatedSince},
+	} {
+		if bound.spec == "" {
+			continue
+		}
+		t, err := bound.spec.Resolve(now)
+		if err != nil {
+			return nil, err
+		}
+		*bound.dst = &t
+	}
+	return m, nil
+}
+
+// Namespace returns the namespace matched, or "" for every namespace.
+func (m *Matcher) Namespace() resource.Namespace { return m.namespace }
+
+// Match reports whether res passes every part of the filter.
+func (m *Matcher) Match(res resource.Resource) bool {
+	created, updated := time.Time(res.CreatedAt), time.Time(res.UpdatedAt)
+	return (m.namespace == "" || res.Namespace == m.namespace) &&
+		(!res.Deleted() || m.includeDeleted) &&
+		m.names.Match(res.Name) &&
+		(len(m.statuses) == 0 || slices.Contains(m.statuses, res.Status)) &&
+		m.selector.Matches(res.Labels) &&
+		m.where.Matches(res) &&
+		(m.CreatedAfter == nil || created.After(*m.CreatedAfter)) &&
+		(m.CreatedBefore == nil || created.Before(*m.CreatedBefore)) &&
+		(m.UpdatedSince == nil || !updated.Before(*m.UpdatedSince))
+}
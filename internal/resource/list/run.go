@@ -4,6 +4,8 @@ package list
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
 	"time"
 
@@ -12,22 +14,37 @@ import (
 
 // Resource represents a single resource in the list
 type Resource struct {
-	ID        resource.ID        `json:"id"`
-	Name      resource.Name      `json:"name"`
-	Status    resource.Status    `json:"status"`
-	Tags      []resource.Tag     `json:"tags"`
-	CreatedAt resource.CreatedAt `json:"created_at"`
+	ID        resource.ID         `json:"id"`
+	Namespace resource.Namespace  `json:"namespace"`
+	Version   resource.Version    `json:"version"`
+	Name      resource.Name       `json:"name"`
+	Kind      resource.Kind       `json:"kind,omitempty"`
+	Status    resource.Status     `json:"status"`
+	Tags      []resource.Tag      `json:"tags"`
+	Labels    resource.Labels     `json:"labels,omitempty"`
+	CreatedAt resource.CreatedAt  `json:"created_at"`
+	UpdatedAt resource.UpdatedAt  `json:"updated_at"`
+	ExpiresAt *resource.ExpiresAt `json:"expires_at,omitempty"`
+	DeletedAt *resource.DeletedAt `json:"deleted_at,omitempty"`
 }
 
 // Result holds the result of resource listing
 type Result struct {
 	Resources       []Resource         `json:"resources"`
+	Namespace       resource.Namespace `json:"namespace,omitempty"` // Empty with --all-namespaces
 	Total           int                `json:"total"`
 	Limit           resource.Limit     `json:"limit"`
 	Offset          resource.Offset    `json:"offset"`
+	NextCursor      resource.Cursor    `json:"next_cursor,omitempty"`
 	IncludePatterns resource.Pattern   `json:"include_patterns,omitempty"`
 	ExcludePatterns resource.Pattern   `json:"exclude_patterns,omitempty"`
 	FilterStatuses  []resource.Status  `json:"filter_statuses,omitempty"`
+	Selector        resource.Selector  `json:"selector,omitempty"`
+	Where           resource.Where     `json:"where,omitempty"`
+	CreatedAfter    *time.Time         `json:"created_after,omitempty"`
+	CreatedBefore   *time.Time         `json:"created_before,omitempty"`
+	UpdatedSince    *time.Time         `json:"updated_since,omitempty"`
+	IncludeDeleted  bool               `json:"include_deleted,omitempty"`
 	SortBy          resource.SortField `json:"sort_by"`
 	Ascending       bool               `json:"ascending"`
 }
@@ -38,14 +55,44 @@ func (r Result) MarshalJSON() ([]byte, error) {
 	return json.Marshal((Alias)(r))
 }
 
-// Run executes the resource listing logic (noop stub)
+// openStore opens the resource store.
+var openStore resource.OpenFunc = resource.Open
+
+// fromResource converts a stored resource into its list representation
+func fromResource(res resource.Resource) Resource {
+	return Resource{
+		ID:        res.ID,
+		Namespace: res.Namespace,
+		Version:   res.Version,
+		Name:      res.Name,
+		Kind:      res.Kind,
+		Status:    res.Status,
+		Tags:      res.Tags,
+		Labels:    res.Labels,
+		CreatedAt: res.CreatedAt,
+		UpdatedAt: res.UpdatedAt,
+		ExpiresAt: res.ExpiresAt,
+		DeletedAt: res.DeletedAt,
+	}
+}
+
+// Run lists resources from the store
 func Run(ctx context.Context, logger *slog.Logger, cfg Config) (Result, error) {
 	logger.Info("Listing resources",
+		"namespace", cfg.Namespace,
+		"all_namespaces", cfg.AllNamespaces,
 		"include", cfg.IncludePatterns,
 		"exclude", cfg.ExcludePatterns,
 		"statuses", cfg.Statuses,
+		"selector", cfg.Selector,
+		"where", cfg.Where,
+		"created_after", cfg.CreatedAfter,
+		"created_before", cfg.CreatedBefore,
+		"updated_since", cfg.UpdatedSince,
+		"include_deleted", cfg.IncludeDeleted,
 		"limit", cfg.Limit,
 		"offset", cfg.Offset,
+		"cursor", cfg.Cursor,
 		"sort_by", cfg.SortBy,
 		"ascending", cfg.Ascending,
 	)
@@ -56,67 +103,78 @@ func Run(ctx context.Context, logger *slog.Logger, cfg Config) (Result, error) {
 		statuses = []resource.Status{}
 	}
 
-	// Generate some mock resources
-	resources := []Resource{
-		{
-			ID:        "res-001",
-			Name:      "example-resource-1",
-			Status:    resource.StatusActive,
-			Tags:      []resource.Tag{"prod", "critical"},
-			CreatedAt: resource.CreatedAt(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)),
-		},
-		{
-			ID:        "res-002",
-			Name:      "example-resource-2",
-			Status:    resource.StatusInactive,
-			Tags:      []resource.Tag{"dev", "test"},
-			CreatedAt: resource.CreatedAt(time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)),
-		},
-		{
-			ID:        "res-003",
-			Name:      "example-resource-3",
-			Status:    resource.StatusActive,
-			Tags:      []resource.Tag{"staging"},
-			CreatedAt: resource.CreatedAt(time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)),
-		},
+	match, err := cfg.Filter.Matcher(time.Now().UTC())
+	if err != nil {
+		return Result{}, err
 	}
 
-	// Filter by statuses if provided
-	if len(statuses) > 0 {
-		filtered := []Resource{}
-		for _, res := range resources {
-			for _, status := range statuses {
-				if res.Status == status {
-					filtered = append(filtered, res)
-					break
-				}
-			}
-		}
-		resources = filtered
+	if cfg.Cursor != "" && cfg.Offset != 0 {
+		return Result{}, errors.New("--cursor and --offset are mutually exclusive")
 	}
 
-	// Apply limit and offset (convert custom types to int for arithmetic)
-	total := len(resources)
-	start := int(cfg.Offset)
-	end := int(cfg.Offset) + int(cfg.Limit)
+	sortKeys, err := resource.ParseSortKeys(cfg.SortBy, cfg.Ascending)
+	if err != nil {
+		return Result{}, err
+	}
 
-	if start >= total {
-		resources = []Resource{}
-	} else {
-		if end > total || int(cfg.Limit) == 0 {
-			end = total
+	store, err := openStore(cfg.State)
+	if err != nil {
+		return Result{}, fmt.Errorf("open resource store: %w", err)
+	}
+
+	stored, err := store.List(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+
+	resource.Sort(stored, sortKeys)
+
+	matched := []resource.Resource{}
+	for _, res := range stored {
+		if match.Match(res) {
+			matched = append(matched, res)
+		}
+	}
+
+	// Apply the cursor or offset, then the limit (convert custom types to int for arithmetic)
+	total := len(matched)
+	page := matched
+	if cfg.Cursor != "" {
+		page, err = cfg.Cursor.Seek(page, sortKeys)
+		if err != nil {
+			return Result{}, err
 		}
-		resources = resources[start:end]
+	} else {
+		page = page[min(int(cfg.Offset), total):]
+	}
+
+	var nextCursor resource.Cursor
+	if int(cfg.Limit) > 0 && len(page) > int(cfg.Limit) {
+		page = page[:cfg.Limit]
+		nextCursor = resource.NewCursor(sortKeys, page[len(page)-1])
+	}
+
+	resources := make([]Resource, 0, len(page))
+	for _, res := range page {
+		resources = append(resources, fromResource(res))
 	}
 
 	result := Result{
 		Resources:       resources,
+		Namespace:       match.Namespace(),
 		Total:           total,
 		Limit:           cfg.Limit,
 		Offset:          cfg.Offset,
+		NextCursor:      nextCursor,
 		IncludePatterns: cfg.IncludePatterns,
 		ExcludePatterns: cfg.ExcludePatterns,
 		FilterStatuses:  statuses,
+		Selector:        cfg.Selector,
+		Where:           cfg.Where,
+		CreatedAfter:    match.CreatedAfter,
+		CreatedBefore:   match.CreatedBefore,
+		UpdatedSince:    match.UpdatedSince,
+		IncludeDeleted:  cfg.IncludeDeleted,
 		SortBy:          cfg.SortBy,
 		Ascending:       cfg.Ascending,
 	}
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package resource
+
+// lockFile is a no-op where flock is unavailable; only the in-process lock
+// of FileStore applies.
+func lockFile(string, bool) (unlock func(), err error) {
+	return func() {}, nil
+}
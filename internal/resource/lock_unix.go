@@ -0,0 +1,37 @@
+//go:build unix
+
+package resource
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// lockFile takes an advisory lock on the file at path, creating it if needed,
+// and returns a function releasing it. The lock is exclusive when exclusive
+// is set and shared otherwise; it blocks until granted.
+func lockFile(path string, exclusive bool) (unlock func(), err error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open lock file: %w", err)
+	}
+	how := syscall.LOCK_SH
+	if exclusive {
+		how = syscall.LOCK_EX
+	}
+	for {
+		err = syscall.Flock(int(f.Fd()), how)
+		if err != syscall.EINTR {
+			break
+		}
+	}
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("lock state file: %w", err)
+	}
+	return func() {
+		syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		f.Close()
+	}, nil
+}
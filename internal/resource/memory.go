@@ -0,0 +1,127 @@
+package resource
+
+import (
+	"context"
+	"maps"
+	"sync"
+)
+
+// MemoryStore is a Store kept entirely in memory, intended for tests.
+type MemoryStore struct {
+	mu    sync.RWMutex
+	state *state
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{state: newState()}
+}
+
+// OpenMemory returns an OpenFunc that always yields the given store, ignoring the state directory.
+func OpenMemory(store *MemoryStore) OpenFunc {
+	return func(StateDir) (Store, error) { return store, nil }
+}
+
+func (m *MemoryStore) Create(ctx context.Context, res Resource) (Resource, error) {
+	if err := ctx.Err(); err != nil {
+		return Resource{}, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.state.create(res)
+}
+
+func (m *MemoryStore) Get(ctx context.Context, id ID) (Resource, error) {
+	if err := ctx.Err(); err != nil {
+		return Resource{}, err
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.state.get(id)
+}
+
+func (m *MemoryStore) List(ctx context.Context) ([]Resource, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.state.list(), nil
+}
+
+func (m *MemoryStore) Update(ctx context.Context, res Resource) (Resource, error) {
+	if err := ctx.Err(); err != nil {
+		return Resource{}, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.state.update(res)
+}
+
+func (m *MemoryStore) Delete(ctx context.Context, id ID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.state.delete(id)
+}
+
+func (m *MemoryStore) History(ctx context.Context, id ID) ([]Revision, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.state.history(id)
+}
+
+func (m *MemoryStore) Batch(ctx context.Context, fn func(Store) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Stored resources are never mutated in place, so shallow copies of the
+	// maps are enough to discard a failed batch.
+	working := &state{
+		Resources:  maps.Clone(m.state.Resources),
+		Revisions:  maps.Clone(m.state.Revisions),
+		Namespaces: maps.Clone(m.state.Namespaces),
+	}
+	if err := fn(batch{state: working}); err != nil {
+		return err
+	}
+	m.state = working
+	return nil
+}
+
+func (m *MemoryStore) Namespaces(ctx context.Context) ([]NamespaceInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.state.namespaces(), nil
+}
+
+func (m *MemoryStore) CreateNamespace(ctx context.Context, ns Namespace) (NamespaceInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return NamespaceInfo{}, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.state.createNamespace(ns)
+}
+
+func (m *MemoryStore) DeleteNamespace(ctx context.Context, ns Namespace) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.state.deleteNamespace(ns)
+}
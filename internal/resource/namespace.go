@@ -0,0 +1,148 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Namespace scopes resources: names are unique among the live resources of a
+// namespace, and references resolve within one.
+type Namespace string
+
+// DefaultNamespace holds resources created without a namespace. It always
+// exists and cannot be deleted.
+const DefaultNamespace Namespace = "default"
+
+// ErrNamespaceNotEmpty is returned when deleting a namespace that still holds resources.
+var ErrNamespaceNotEmpty = errors.New("namespace is not empty")
+
+// Validate checks that the namespace is a DNS label, like a name.
+func (n Namespace) Validate() error {
+	if err := Name(n).Validate(); err != nil {
+		return errors.New(strings.Replace(err.Error(), "name", "namespace", 1))
+	}
+	return nil
+}
+
+// NamespaceInfo describes a namespace.
+type NamespaceInfo struct {
+	Name      Namespace `json:"name"`
+	CreatedAt CreatedAt `json:"created_at,omitzero"` // Zero for DefaultNamespace
+}
+
+// NamespaceNotFoundError reports a namespace that has not been created.
+type NamespaceNotFoundError struct {
+	Namespace Namespace
+}
+
+func (e *NamespaceNotFoundError) Error() string {
+	return fmt.Sprintf("namespace %q not found; create it first", e.Namespace)
+}
+func (e *NamespaceNotFoundError) Is(target error) bool { return target == ErrNotFound }
+
+// ExitCode implements cli.ExitCoder.
+func (e *NamespaceNotFoundError) ExitCode() int { return ExitCodeNotFound }
+
+// NamespaceExistsError reports creating a namespace that already exists.
+type NamespaceExistsError struct {
+	Namespace Namespace
+}
+
+func (e *NamespaceExistsError) Error() string {
+	return fmt.Sprintf("namespace %q already exists", e.Namespace)
+}
+func (e *NamespaceExistsError) Is(target error) bool { return target == ErrAlreadyExists }
+
+// ExitCode implements cli.ExitCoder.
+func (e *NamespaceExistsError) ExitCode() int { return ExitCodeAlreadyExists }
+
+// NamespaceNotEmptyError reports deleting a namespace that still holds
+// resources, including those in the trash.
+type NamespaceNotEmptyError struct {
+	Namespace Namespace
+	Resources int
+}
+
+func (e *NamespaceNotEmptyError) Error() string {
+	return fmt.Sprintf("namespace %q still holds %d resources; delete and purge them first", e.Namespace, e.Resources)
+}
+func (e *NamespaceNotEmptyError) Is(target error) bool { return target == ErrNamespaceNotEmpty }
+
+// ExitCode implements cli.ExitCoder.
+func (e *NamespaceNotEmptyError) ExitCode() int { return ExitCodeNotEmpty }
+
+// CheckNamespace returns a NamespaceNotFoundError unless ns exists in store.
+func CheckNamespace(ctx context.Context, store Store, ns Namespace) error {
+	all, err := store.Namespaces(ctx)
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(all, func(info NamespaceInfo) bool { return info.Name == ns }) {
+		return &NamespaceNotFoundError{Namespace: ns}
+	}
+	return nil
+}
+
+// InNamespace returns the resources in ns, keeping their order. An empty ns
+// selects every namespace.
+func InNamespace(resources []Resource, ns Namespace) []Resource {
+	if ns == "" {
+		return resources
+	}
+	out := make([]Resource, 0, len(resources))
+	for _, res := range resources {
+		if res.Namespace == ns {
+			out = append(out, res)
+		}
+	}
+	return out
+}
+
+// namespaces returns every namespace in name order, including DefaultNamespace.
+func (s *state) namespaces() []NamespaceInfo {
+	all := maps.Clone(s.Namespaces)
+	if _, ok := all[DefaultNamespace]; !ok {
+		all[DefaultNamespace] = NamespaceInfo{Name: DefaultNamespace}
+	}
+	out := make([]NamespaceInfo, 0, len(all))
+	for _, ns := range slices.Sorted(maps.Keys(all)) {
+		out = append(out, all[ns])
+	}
+	return out
+}
+
+func (s *state) createNamespace(ns Namespace) (NamespaceInfo, error) {
+	if err := ns.Validate(); err != nil {
+		return NamespaceInfo{}, err
+	}
+	if s.hasNamespace(ns) {
+		return NamespaceInfo{}, &NamespaceExistsError{Namespace: ns}
+	}
+	info := NamespaceInfo{Name: ns, CreatedAt: CreatedAt(time.Now().UTC())}
+	s.Namespaces[ns] = info
+	return info, nil
+}
+
+func (s *state) deleteNamespace(ns Namespace) error {
+	if ns == DefaultNamespace {
+		return fmt.Errorf("namespace %q cannot be deleted", ns)
+	}
+	if !s.hasNamespace(ns) {
+		return &NamespaceNotFoundError{Namespace: ns}
+	}
+	if n := len(InNamespace(s.list(), ns)); n > 0 {
+		return &NamespaceNotEmptyError{Namespace: ns, Resources: n}
+	}
+	delete(s.Namespaces, ns)
+	return nil
+}
+
+func (s *state) hasNamespace(ns Namespace) bool {
+	_, ok := s.Namespaces[ns]
+	return ok || ns == DefaultNamespace
+}
@@ -0,0 +1,50 @@
+// Package create implements namespace creation logic
+package create
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// Result holds the result of namespace creation
+type Result struct {
+	Success   bool               `json:"success"`
+	Namespace resource.Namespace `json:"namespace"`
+	CreatedAt resource.CreatedAt `json:"created_at"`
+	Message   resource.Message   `json:"message"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (r Result) MarshalJSON() ([]byte, error) {
+	type Alias Result
+	return json.Marshal((Alias)(r))
+}
+
+// openStore opens the resource store.
+var openStore resource.OpenFunc = resource.Open
+
+// Run creates the namespace in the store
+func Run(ctx context.Context, logger *slog.Logger, cfg Config) (Result, error) {
+	logger.Info("Creating namespace", "namespace", cfg.Name)
+
+	store, err := openStore(cfg.State)
+	if err != nil {
+		return Result{}, fmt.Errorf("open resource store: %w", err)
+	}
+	info, err := store.CreateNamespace(ctx, cfg.Name)
+	if err != nil {
+		return Result{}, err
+	}
+
+	logger.Info("Namespace creation complete", "namespace", info.Name)
+	return Result{
+		Success:   true,
+		Namespace: info.Name,
+		CreatedAt: info.CreatedAt,
+		Message:   "Namespace created successfully",
+	}, nil
+}
@@ -0,0 +1,17 @@
+package list
+
+import (
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/app/log"
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// Config holds configuration for listing namespaces
+type Config struct {
+	State   resource.StateDir // Resource store directory
+	Output  app.FilePath      // Output file path
+	Logging log.Config
+}
+
+func (c Config) OutputFilePath() app.FilePath { return c.Output }
+func (c Config) LoggerConfig() log.Config     { return c.Logging }
@@ -0,0 +1,68 @@
+// Package list implements listing namespaces
+package list
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// Namespace is a namespace with the number of resources it holds
+type Namespace struct {
+	Name      resource.Namespace `json:"name"`
+	CreatedAt resource.CreatedAt `json:"created_at,omitzero"`
+	Resources int                `json:"resources"` // Live resources
+	Deleted   int                `json:"deleted"`   // Resources in the trash
+}
+
+// Result holds every namespace
+type Result struct {
+	Namespaces []Namespace `json:"namespaces"`
+	Total      int         `json:"total"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (r Result) MarshalJSON() ([]byte, error) {
+	type Alias Result
+	return json.Marshal((Alias)(r))
+}
+
+// openStore opens the resource store.
+var openStore resource.OpenFunc = resource.Open
+
+// Run lists every namespace, in name order, with its resource counts
+func Run(ctx context.Context, logger *slog.Logger, cfg Config) (Result, error) {
+	logger.Info("Listing namespaces")
+
+	store, err := openStore(cfg.State)
+	if err != nil {
+		return Result{}, fmt.Errorf("open resource store: %w", err)
+	}
+	namespaces, err := store.Namespaces(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	all, err := store.List(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+
+	result := Result{Namespaces: make([]Namespace, 0, len(namespaces)), Total: len(namespaces)}
+	for _, info := range namespaces {
+		ns := Namespace{Name: info.Name, CreatedAt: info.CreatedAt}
+		for _, res := range resource.InNamespace(all, info.Name) {
+			if res.Deleted() {
+				ns.Deleted++
+			} else {
+				ns.Resources++
+			}
+		}
+		result.Namespaces = append(result.Namespaces, ns)
+	}
+
+	logger.Info("Namespace listing complete", "total", result.Total)
+	return result, nil
+}
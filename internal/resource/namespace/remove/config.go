@@ -0,0 +1,31 @@
+package remove
+
+import (
+	"errors"
+
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/app/log"
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// Config holds configuration for deleting a namespace
+type Config struct {
+	Name    resource.Namespace // Namespace name
+	State   resource.StateDir  // Resource store directory
+	Output  app.FilePath       // Output file path
+	Logging log.Config
+}
+
+func (c Config) OutputFilePath() app.FilePath { return c.Output }
+func (c Config) LoggerConfig() log.Config     { return c.Logging }
+
+// Validate implements app.Validator
+func (c Config) Validate() error {
+	var v app.ValidationError
+	if c.Name == "" {
+		v.Check("namespace", errors.New("is required"))
+	} else {
+		v.Check("namespace", c.Name.Validate())
+	}
+	return v.Err()
+}
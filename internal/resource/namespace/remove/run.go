@@ -0,0 +1,43 @@
+// Package remove implements namespace deletion logic
+package remove
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// Result holds the result of namespace deletion
+type Result struct {
+	Success   bool               `json:"success"`
+	Namespace resource.Namespace `json:"namespace"`
+	Message   resource.Message   `json:"message"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (r Result) MarshalJSON() ([]byte, error) {
+	type Alias Result
+	return json.Marshal((Alias)(r))
+}
+
+// openStore opens the resource store.
+var openStore resource.OpenFunc = resource.Open
+
+// Run deletes the namespace, which must hold no resources, not even in the trash
+func Run(ctx context.Context, logger *slog.Logger, cfg Config) (Result, error) {
+	logger.Info("Deleting namespace", "namespace", cfg.Name)
+
+	store, err := openStore(cfg.State)
+	if err != nil {
+		return Result{}, fmt.Errorf("open resource store: %w", err)
+	}
+	if err := store.DeleteNamespace(ctx, cfg.Name); err != nil {
+		return Result{}, err
+	}
+
+	logger.Info("Namespace deletion complete", "namespace", cfg.Name)
+	return Result{Success: true, Namespace: cfg.Name, Message: "Namespace deleted"}, nil
+}
@@ -0,0 +1,94 @@
+package resource
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// RegexPrefix marks a pattern as a regular expression rather than a glob.
+const RegexPrefix = "re:"
+
+// PatternMatcher matches resource names against a compiled Pattern.
+// The zero PatternMatcher has no patterns and matches nothing.
+type PatternMatcher struct {
+	globs   []string
+	regexps []*regexp.Regexp
+}
+
+// Compile parses a comma-separated list of patterns. Each entry is a glob
+// (see path.Match) unless prefixed with "re:", in which case it is a regular
+// expression. Use \x2c for a literal comma inside a regular expression.
+func (p Pattern) Compile() (PatternMatcher, error) {
+	var m PatternMatcher
+	for _, entry := range strings.Split(string(p), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		if expr, ok := strings.CutPrefix(entry, RegexPrefix); ok {
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return PatternMatcher{}, fmt.Errorf("invalid regular expression %q: %w", expr, err)
+			}
+			m.regexps = append(m.regexps, re)
+			continue
+		}
+
+		if _, err := path.Match(entry, ""); err != nil {
+			return PatternMatcher{}, fmt.Errorf("invalid glob %q: %w", entry, err)
+		}
+		m.globs = append(m.globs, entry)
+	}
+	return m, nil
+}
+
+// Empty reports whether the matcher has no patterns.
+func (m PatternMatcher) Empty() bool {
+	return len(m.globs) == 0 && len(m.regexps) == 0
+}
+
+// Match reports whether name matches any of the patterns.
+func (m PatternMatcher) Match(name Name) bool {
+	for _, glob := range m.globs {
+		if ok, _ := path.Match(glob, string(name)); ok {
+			return true
+		}
+	}
+	for _, re := range m.regexps {
+		if re.MatchString(string(name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// NameFilter selects resource names by include and exclude patterns.
+type NameFilter struct {
+	Include PatternMatcher
+	Exclude PatternMatcher
+}
+
+// NewNameFilter compiles include and exclude patterns into a NameFilter.
+func NewNameFilter(include, exclude Pattern) (NameFilter, error) {
+	in, err := include.Compile()
+	if err != nil {
+		return NameFilter{}, fmt.Errorf("include patterns: %w", err)
+	}
+	ex, err := exclude.Compile()
+	if err != nil {
+		return NameFilter{}, fmt.Errorf("exclude patterns: %w", err)
+	}
+	return NameFilter{Include: in, Exclude: ex}, nil
+}
+
+// Match reports whether name passes the filter. Exclusions take precedence,
+// and an empty include list admits every name.
+func (f NameFilter) Match(name Name) bool {
+	if f.Exclude.Match(name) {
+		return false
+	}
+	return f.Include.Empty() || f.Include.Match(name)
+}
@@ -0,0 +1,99 @@
+// Package purge implements permanent removal of trashed resources
+package purge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// Result holds the result of purging the trash
+type Result struct {
+	Success   bool             `json:"success"`
+	Purged    []resource.ID    `json:"purged"`
+	Count     int              `json:"count"`
+	Kept      []resource.ID    `json:"kept,omitempty"`
+	OlderThan string           `json:"older_than"`
+	DryRun    bool             `json:"dry_run"`
+	Message   resource.Message `json:"message"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (r Result) MarshalJSON() ([]byte, error) {
+	type Alias Result
+	return json.Marshal((Alias)(r))
+}
+
+// openStore opens the resource store.
+var openStore resource.OpenFunc = resource.Open
+
+// Run permanently removes resources that have been in the trash longer than cfg.OlderThan
+func Run(ctx context.Context, logger *slog.Logger, cfg Config) (Result, error) {
+	logger.Info("Purging deleted resources",
+		"namespace", cfg.Namespace,
+		"older_than", time.Duration(cfg.OlderThan),
+		"dry_run", cfg.DryRun,
+	)
+
+	store, err := openStore(cfg.State)
+	if err != nil {
+		return Result{}, fmt.Errorf("open resource store: %w", err)
+	}
+
+	all, err := store.List(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+
+	cutoff := time.Now().Add(-time.Duration(cfg.OlderThan))
+	var expired []resource.ID
+	for _, res := range resource.InNamespace(all, cfg.Namespace) {
+		if res.Deleted() && !time.Time(*res.DeletedAt).After(cutoff) {
+			expired = append(expired, res.ID)
+		}
+	}
+
+	// A resource stays while anything not being purged, trashed or not,
+	// still depends on it.
+	purged, held := resource.NewGraph(all).Removable(expired, true)
+	kept := slices.Sorted(maps.Keys(held))
+	for _, id := range kept {
+		logger.Warn("Deleted resource kept for its dependents", "resource_id", id, "dependents", held[id])
+	}
+	if !cfg.DryRun && len(purged) > 0 {
+		// One batch, so that a failure partway leaves the trash as it was.
+		err := store.Batch(ctx, func(tx resource.Store) error {
+			for _, id := range purged {
+				if err := tx.Delete(ctx, id); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return Result{}, err
+		}
+	}
+
+	result := Result{
+		Success:   true,
+		Purged:    append([]resource.ID{}, purged...),
+		Count:     len(purged),
+		Kept:      kept,
+		OlderThan: time.Duration(cfg.OlderThan).String(),
+		DryRun:    cfg.DryRun,
+		Message:   "Trash purged successfully",
+	}
+	if cfg.DryRun {
+		result.Message = "Dry run: would have purged resources"
+	}
+
+	logger.Info("Resource purge complete", "count", result.Count)
+	return result, nil
+}
@@ -0,0 +1,41 @@
+package reap
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/app/log"
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// Config holds configuration for reaping expired resources
+type Config struct {
+	DryRun        bool               // Report what would be reaped without changing anything
+	Force         bool               // Delete permanently instead of moving to the trash
+	Interval      time.Duration      // Time between passes; 0 runs a single pass
+	Namespace     resource.Namespace // Namespace of the resources
+	AllNamespaces bool               // Reap resources of every namespace instead
+	State         resource.StateDir  // Resource store directory
+	Output        app.FilePath       // Output file path
+	Logging       log.Config
+}
+
+func (c Config) OutputFilePath() app.FilePath { return c.Output }
+func (c Config) LoggerConfig() log.Config     { return c.Logging }
+
+// Validate implements app.Validator
+func (c Config) Validate() error {
+	var v app.ValidationError
+	if !c.AllNamespaces {
+		v.Check("namespace", c.Namespace.Validate())
+	}
+	if c.Interval < 0 {
+		v.Check("interval", errors.New("must not be negative"))
+	}
+	if c.DryRun && c.Interval > 0 {
+		// Nothing changes between passes, so each would report the same resources.
+		v.Check("dry-run", errors.New("cannot be combined with --interval"))
+	}
+	return v.Err()
+}
@@ -0,0 +1,31 @@
+package reap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		cfg   Config
+		valid bool
+	}{
+		{"single pass", Config{Namespace: resource.DefaultNamespace}, true},
+		{"dry run", Config{Namespace: resource.DefaultNamespace, DryRun: true}, true},
+		{"interval", Config{Namespace: resource.DefaultNamespace, Interval: time.Minute}, true},
+		{"negative interval", Config{Namespace: resource.DefaultNamespace, Interval: -time.Minute}, false},
+		{"dry run with interval", Config{Namespace: resource.DefaultNamespace, DryRun: true, Interval: time.Minute}, false},
+		{"every namespace", Config{AllNamespaces: true}, true},
+		{"no namespace", Config{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg.Validate(); (err == nil) != tt.valid {
+				t.Errorf("Validate = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
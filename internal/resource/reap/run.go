@@ -0,0 +1,190 @@
+// Package reap implements deleting expired resources
+package reap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// Reaped is an expired resource that a pass deleted
+type Reaped struct {
+	ID        resource.ID        `json:"id"`
+	Namespace resource.Namespace `json:"namespace"`
+	Name      resource.Name      `json:"name"`
+	ExpiresAt resource.ExpiresAt `json:"expires_at"`
+}
+
+// Skipped is an expired resource that was kept because resources that have
+// not expired depend on it
+type Skipped struct {
+	ID         resource.ID        `json:"id"`
+	Namespace  resource.Namespace `json:"namespace"`
+	Name       resource.Name      `json:"name"`
+	ExpiresAt  resource.ExpiresAt `json:"expires_at"`
+	Dependents []resource.ID      `json:"dependents"`
+}
+
+// Pass is the outcome of one pass over the store, written as one line of
+// NDJSON. Resources are reaped dependents first.
+type Pass struct {
+	At        time.Time `json:"at"`
+	DryRun    bool      `json:"dry_run"`
+	Permanent bool      `json:"permanent"`
+	Reaped    []Reaped  `json:"reaped"`
+	Skipped   []Skipped `json:"skipped,omitempty"`
+}
+
+// Result summarises a reap once it stops. Passes are streamed while it runs,
+// so the result itself is not written.
+type Result struct {
+	Success bool             `json:"success"`
+	Passes  int              `json:"passes"`
+	Reaped  int              `json:"reaped"`
+	Message resource.Message `json:"message"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (r Result) MarshalJSON() ([]byte, error) {
+	type Alias Result
+	return json.Marshal((Alias)(r))
+}
+
+// Streamed implements app.Streamer
+func (Result) Streamed() {}
+
+// openStore opens the resource store.
+var openStore resource.OpenFunc = resource.Open
+
+// Run deletes the resources whose expiry has passed. Without an interval it
+// makes a single pass; otherwise it makes one pass every cfg.Interval until
+// ctx is cancelled, writing only the passes that reaped something.
+// Cancellation is a clean stop, not an error.
+func Run(ctx context.Context, logger *slog.Logger, cfg Config) (Result, error) {
+	logger.Info("Reaping expired resources",
+		"namespace", cfg.Namespace,
+		"all_namespaces", cfg.AllNamespaces,
+		"dry_run", cfg.DryRun,
+		"force", cfg.Force,
+		"interval", cfg.Interval,
+	)
+
+	store, err := openStore(cfg.State)
+	if err != nil {
+		return Result{}, fmt.Errorf("open resource store: %w", err)
+	}
+
+	out, err := app.OpenOutput(logger, cfg.Output)
+	if err != nil {
+		return Result{}, err
+	}
+	defer out.Close()
+	enc := json.NewEncoder(out)
+
+	result := Result{Success: true, Message: "Reaper stopped"}
+	stop := func(err error) (Result, error) {
+		return app.Stopped(ctx, logger, result, err, "Resource reaper stopped", "passes", result.Passes, "reaped", result.Reaped)
+	}
+	for {
+		pass, err := reap(ctx, store, cfg, time.Now().UTC())
+		if err != nil {
+			return stop(err)
+		}
+		result.Passes++
+		result.Reaped += len(pass.Reaped)
+		for _, s := range pass.Skipped {
+			logger.Warn("Expired resource kept for its dependents", "resource_id", s.ID, "dependents", s.Dependents)
+		}
+
+		if cfg.Interval == 0 || len(pass.Reaped) > 0 {
+			if err := enc.Encode(pass); err != nil {
+				return Result{}, fmt.Errorf("write pass: %w", err)
+			}
+		}
+		if cfg.Interval == 0 {
+			result.Message = "Reap complete"
+			logger.Info("Resource reap complete", "reaped", result.Reaped)
+			return result, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return stop(ctx.Err())
+		case <-time.After(cfg.Interval):
+		}
+	}
+}
+
+// reap makes one pass: it deletes every expired resource in scope, except
+// those that resources staying behind depend on.
+func reap(ctx context.Context, store resource.Store, cfg Config, now time.Time) (Pass, error) {
+	pass := Pass{At: now, DryRun: cfg.DryRun, Permanent: cfg.Force, Reaped: []Reaped{}}
+
+	all, err := store.List(ctx)
+	if err != nil {
+		return Pass{}, err
+	}
+	ns := cfg.Namespace
+	if cfg.AllNamespaces {
+		ns = ""
+	}
+	expired := map[resource.ID]resource.Resource{}
+	for _, res := range resource.InNamespace(all, ns) {
+		if !res.Deleted() && res.Expired(now) {
+			expired[res.ID] = res
+		}
+	}
+
+	// A resource can only go along with all of its dependents; a permanent
+	// delete also counts those in the trash.
+	order, held := resource.NewGraph(all).Removable(slices.Collect(maps.Keys(expired)), cfg.Force)
+	for _, id := range slices.Sorted(maps.Keys(held)) {
+		res := expired[id]
+		pass.Skipped = append(pass.Skipped, Skipped{
+			ID:         res.ID,
+			Namespace:  res.Namespace,
+			Name:       res.Name,
+			ExpiresAt:  *res.ExpiresAt,
+			Dependents: held[id],
+		})
+	}
+	for _, id := range order {
+		res := expired[id]
+		pass.Reaped = append(pass.Reaped, Reaped{ID: res.ID, Namespace: res.Namespace, Name: res.Name, ExpiresAt: *res.ExpiresAt})
+	}
+	if cfg.DryRun || len(order) == 0 {
+		return pass, nil
+	}
+
+	deletedAt := resource.DeletedAt(now)
+	err = store.Batch(ctx, func(tx resource.Store) error {
+		for _, id := range order {
+			if cfg.Force {
+				if err := tx.Delete(ctx, id); err != nil {
+					return err
+				}
+				continue
+			}
+			current, err := tx.Get(ctx, id)
+			if err != nil {
+				return err
+			}
+			current.DeletedAt = &deletedAt
+			if _, err := tx.Update(ctx, current); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return Pass{}, fmt.Errorf("reap: %w", err)
+	}
+	return pass, nil
+}
@@ -0,0 +1,36 @@
+package remove
+
+import (
+	"errors"
+
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/app/log"
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// Config holds configuration for resource deletion
+type Config struct {
+	Ref       resource.Ref       // Resource ID or name
+	DryRun    bool               // Dry run mode
+	Force     bool               // Delete permanently instead of moving to the trash
+	Cascade   bool               // Also delete every resource that depends on it
+	IfVersion resource.Version   // Expected current version (0 = any)
+	Namespace resource.Namespace // Namespace of the resource
+	State     resource.StateDir  // Resource store directory
+	Output    app.FilePath       // Output file path
+	Logging   log.Config
+}
+
+func (c Config) OutputFilePath() app.FilePath { return c.Output }
+func (c Config) LoggerConfig() log.Config     { return c.Logging }
+
+// Validate implements app.Validator
+func (c Config) Validate() error {
+	var v app.ValidationError
+	v.Check("namespace", c.Namespace.Validate())
+	v.Check("id|name", c.Ref.Validate())
+	if c.IfVersion < 0 {
+		v.Check("if-version", errors.New("must not be negative"))
+	}
+	return v.Err()
+}
@@ -0,0 +1,141 @@
+// Package remove implements resource deletion logic
+package remove
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// Result holds the result of resource deletion
+type Result struct {
+	Success    bool                `json:"success"`
+	ResourceID resource.ID         `json:"resource_id"`
+	Name       resource.Name       `json:"name"`
+	Version    resource.Version    `json:"version"`
+	DeletedAt  *resource.DeletedAt `json:"deleted_at,omitempty"`
+	Permanent  bool                `json:"permanent"`
+	DryRun     bool                `json:"dry_run"`
+	Force      bool                `json:"force"`
+	Cascaded   []resource.ID       `json:"cascaded,omitempty"`
+	Message    resource.Message    `json:"message"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (r Result) MarshalJSON() ([]byte, error) {
+	type Alias Result
+	return json.Marshal((Alias)(r))
+}
+
+// openStore opens the resource store.
+var openStore resource.OpenFunc = resource.Open
+
+// Run moves a resource to the trash, or removes it permanently when forced
+func Run(ctx context.Context, logger *slog.Logger, cfg Config) (Result, error) {
+	logger.Info("Deleting resource",
+		"namespace", cfg.Namespace, "ref", cfg.Ref,
+		"dry_run", cfg.DryRun,
+		"force", cfg.Force,
+		"cascade", cfg.Cascade,
+		"if_version", cfg.IfVersion,
+	)
+
+	store, err := openStore(cfg.State)
+	if err != nil {
+		return Result{}, fmt.Errorf("open resource store: %w", err)
+	}
+
+	res, err := resource.Find(ctx, store, cfg.Namespace, cfg.Ref)
+	if err != nil {
+		return Result{}, err
+	}
+	if res.Deleted() && !cfg.Force {
+		return Result{}, fmt.Errorf("delete %s: %w; use --force to remove it permanently", res.ID, resource.ErrDeleted)
+	}
+	if err := resource.CheckVersion(res, cfg.IfVersion); err != nil {
+		return Result{}, err
+	}
+
+	// With --cascade, dependents go too. A permanent delete also counts those
+	// already in the trash, so none is left depending on a missing resource.
+	all, err := store.List(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	graph := resource.NewGraph(all)
+	var cascaded []resource.ID
+	if cfg.Cascade {
+		cascaded = graph.Dependents(res.ID, cfg.Force)
+	} else if dependents := graph.Dependents(res.ID, cfg.Force); len(dependents) > 0 {
+		return Result{}, &resource.HasDependentsError{ID: res.ID, Dependents: dependents}
+	}
+
+	result := Result{
+		Success:    true,
+		ResourceID: res.ID,
+		Name:       res.Name,
+		Version:    res.Version,
+		Permanent:  cfg.Force,
+		DryRun:     cfg.DryRun,
+		Force:      cfg.Force,
+		Cascaded:   graph.DeletionOrder(cascaded),
+		Message:    "Resource moved to trash",
+	}
+	if cfg.Force {
+		result.Message = "Resource deleted permanently"
+	}
+
+	if cfg.DryRun {
+		result.Message = "Dry run: would have moved resource to trash"
+		if cfg.Force {
+			result.Message = "Dry run: would have deleted resource permanently"
+		}
+		logger.Info("Resource deletion complete", "resource_id", result.ResourceID)
+		return result, nil
+	}
+
+	deletedAt := resource.DeletedAt(time.Now().UTC())
+	if !cfg.Force {
+		result.DeletedAt = &deletedAt
+	}
+	err = store.Batch(ctx, func(tx resource.Store) error {
+		for _, id := range append(slices.Clone(result.Cascaded), res.ID) {
+			current, err := tx.Get(ctx, id)
+			if err != nil {
+				return err
+			}
+			// Only the named resource is held to the version that was read.
+			if id == res.ID {
+				if err := resource.CheckVersion(current, res.Version); err != nil {
+					return err
+				}
+			}
+			if cfg.Force {
+				if err := tx.Delete(ctx, id); err != nil {
+					return err
+				}
+				continue
+			}
+			current.DeletedAt = &deletedAt
+			updated, err := tx.Update(ctx, current)
+			if err != nil {
+				return err
+			}
+			if id == res.ID {
+				result.Version = updated.Version
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	logger.Info("Resource deletion complete", "resource_id", result.ResourceID, "permanent", result.Permanent)
+	return result, nil
+}
@@ -0,0 +1,91 @@
+// Package restore implements restoring resources from the trash
+package restore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// Result holds the result of a resource restore
+type Result struct {
+	Success    bool             `json:"success"`
+	ResourceID resource.ID      `json:"resource_id"`
+	Name       resource.Name    `json:"name"`
+	Version    resource.Version `json:"version"`
+	Message    resource.Message `json:"message"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (r Result) MarshalJSON() ([]byte, error) {
+	type Alias Result
+	return json.Marshal((Alias)(r))
+}
+
+// openStore opens the resource store.
+var openStore resource.OpenFunc = resource.Open
+
+// Run takes a resource out of the trash
+func Run(ctx context.Context, logger *slog.Logger, cfg Config) (Result, error) {
+	logger.Info("Restoring resource", "namespace", cfg.Namespace, "ref", cfg.Ref)
+
+	store, err := openStore(cfg.State)
+	if err != nil {
+		return Result{}, fmt.Errorf("open resource store: %w", err)
+	}
+
+	res, err := resource.Find(ctx, store, cfg.Namespace, cfg.Ref)
+	if err != nil {
+		return Result{}, err
+	}
+	if !res.Deleted() {
+		// A name matches the live resource before any in the trash, which
+		// could not be restored under it anyway.
+		if resource.ID(cfg.Ref) != res.ID {
+			trashed, err := inTrash(ctx, store, res)
+			if err != nil {
+				return Result{}, err
+			}
+			if trashed {
+				return Result{}, &resource.AlreadyExistsError{ID: res.ID, Name: res.Name}
+			}
+		}
+		return Result{}, fmt.Errorf("restore %s: resource is not deleted", res.ID)
+	}
+	if err := resource.CheckName(ctx, store, res); err != nil {
+		return Result{}, err
+	}
+
+	res.DeletedAt = nil
+	res, err = store.Update(ctx, res)
+	if err != nil {
+		return Result{}, err
+	}
+
+	logger.Info("Resource restore complete", "resource_id", res.ID)
+	return Result{
+		Success:    true,
+		ResourceID: res.ID,
+		Name:       res.Name,
+		Version:    res.Version,
+		Message:    "Resource restored successfully",
+	}, nil
+}
+
+// inTrash reports whether a resource of live's namespace with its name is in
+// the trash.
+func inTrash(ctx context.Context, store resource.Store, live resource.Resource) (bool, error) {
+	all, err := store.List(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, res := range resource.InNamespace(all, live.Namespace) {
+		if res.Name == live.Name && res.Deleted() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,64 @@
+package resource
+
+import (
+	"slices"
+	"time"
+)
+
+// Operation names the kind of mutation recorded in a revision.
+type Operation string
+
+// Revision operations.
+const (
+	OperationCreate  Operation = "create"
+	OperationUpdate  Operation = "update"
+	OperationDelete  Operation = "delete"
+	OperationRestore Operation = "restore"
+)
+
+// RevisionNumber numbers the revisions of a resource, starting at 1.
+type RevisionNumber int
+
+// Revision is an immutable record of a single mutation of a resource.
+type Revision struct {
+	Number    RevisionNumber `json:"revision"`
+	Operation Operation      `json:"operation"`
+	At        time.Time      `json:"at"`
+	Changes   Changes        `json:"changes,omitempty"` // Field-level diff from the previous revision
+	Snapshot  Resource       `json:"snapshot"`          // The resource as it was after the mutation
+}
+
+// operation infers the operation that turned before into after.
+func operation(before, after Resource) Operation {
+	switch {
+	case !before.Deleted() && after.Deleted():
+		return OperationDelete
+	case before.Deleted() && !after.Deleted():
+		return OperationRestore
+	default:
+		return OperationUpdate
+	}
+}
+
+// record appends a revision for res to its history.
+func (s *state) record(op Operation, changes Changes, res Resource) {
+	history := s.Revisions[res.ID]
+	s.Revisions[res.ID] = append(history, Revision{
+		Number:    RevisionNumber(len(history) + 1),
+		Operation: op,
+		At:        time.Now().UTC(),
+		Changes:   changes,
+		Snapshot:  res.clone(),
+	})
+}
+
+func (s *state) history(id ID) ([]Revision, error) {
+	if _, ok := s.Resources[id]; !ok {
+		return nil, &NotFoundError{Ref: Ref(id)}
+	}
+	history := slices.Clone(s.Revisions[id])
+	for i := range history {
+		history[i].Snapshot = history[i].Snapshot.clone()
+	}
+	return history, nil
+}
@@ -0,0 +1,35 @@
+package rollback
+
+import (
+	"errors"
+
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/app/log"
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// Config holds configuration for rolling a resource back to a revision
+type Config struct {
+	Ref        resource.Ref            // Resource ID or name
+	ToRevision resource.RevisionNumber // Revision to restore
+	DryRun     bool                    // Dry run mode
+	Actor      resource.Actor          // Who is making the change
+	Namespace  resource.Namespace      // Namespace of the resource
+	State      resource.StateDir       // Resource store directory
+	Output     app.FilePath            // Output file path
+	Logging    log.Config
+}
+
+// Validate implements app.Validator
+func (c Config) Validate() error {
+	var v app.ValidationError
+	v.Check("namespace", c.Namespace.Validate())
+	v.Check("id|name", c.Ref.Validate())
+	if c.ToRevision < 1 {
+		v.Check("to-revision", errors.New("must be at least 1"))
+	}
+	return v.Err()
+}
+
+func (c Config) OutputFilePath() app.FilePath { return c.Output }
+func (c Config) LoggerConfig() log.Config     { return c.Logging }
@@ -0,0 +1,140 @@
+// Package rollback implements restoring a resource to an earlier revision
+package rollback
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// Result holds the result of a rollback
+type Result struct {
+	Success      bool                    `json:"success"`
+	ResourceID   resource.ID             `json:"resource_id"`
+	Name         resource.Name           `json:"name"`
+	Version      resource.Version        `json:"version"`
+	FromRevision resource.RevisionNumber `json:"from_revision"`
+	ToRevision   resource.RevisionNumber `json:"to_revision"`
+	Changes      resource.Changes        `json:"changes"`
+	DryRun       bool                    `json:"dry_run"`
+	StatusKept   bool                    `json:"status_kept,omitempty"` // The revision's status is not reachable from the current one
+	Message      resource.Message        `json:"message"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (r Result) MarshalJSON() ([]byte, error) {
+	type Alias Result
+	return json.Marshal((Alias)(r))
+}
+
+// openStore opens the resource store.
+var openStore resource.OpenFunc = resource.Open
+
+// openSchemas opens the spec schema registry.
+var openSchemas = resource.OpenSchemas
+
+// Run restores the mutable fields of a resource from one of its revisions.
+// The rollback itself is recorded as a new revision.
+func Run(ctx context.Context, logger *slog.Logger, cfg Config) (Result, error) {
+	logger.Info("Rolling back resource",
+		"namespace", cfg.Namespace, "ref", cfg.Ref,
+		"to_revision", cfg.ToRevision,
+		"actor", cfg.Actor,
+		"dry_run", cfg.DryRun,
+	)
+
+	store, err := openStore(cfg.State)
+	if err != nil {
+		return Result{}, fmt.Errorf("open resource store: %w", err)
+	}
+
+	current, err := resource.Find(ctx, store, cfg.Namespace, cfg.Ref)
+	if err != nil {
+		return Result{}, err
+	}
+	if current.Deleted() {
+		return Result{}, fmt.Errorf("rollback %s: %w; restore it first", current.ID, resource.ErrDeleted)
+	}
+
+	revisions, err := store.History(ctx, current.ID)
+	if err != nil {
+		return Result{}, err
+	}
+	if int(cfg.ToRevision) > len(revisions) {
+		return Result{}, fmt.Errorf("rollback %s: no revision %d (latest is %d)", current.ID, cfg.ToRevision, len(revisions))
+	}
+
+	snapshot := revisions[cfg.ToRevision-1].Snapshot
+	target := restore(current, snapshot)
+	var statusKept error
+	if snapshot.Status != current.Status {
+		statusKept = target.Transition(snapshot.Status, cfg.Actor, time.Now().UTC())
+	}
+	if err := resource.CheckName(ctx, store, target); err != nil {
+		return Result{}, err
+	}
+	if !target.Spec.Equal(current.Spec) {
+		// The schema may have changed since the revision was made.
+		schemas, err := openSchemas(cfg.State)
+		if err != nil {
+			return Result{}, err
+		}
+		if err := schemas.Validate(target.Kind, target.Spec); err != nil {
+			return Result{}, fmt.Errorf("rollback %s: %w", current.ID, err)
+		}
+	}
+
+	result := Result{
+		Success:      true,
+		ResourceID:   current.ID,
+		Name:         target.Name,
+		Version:      current.Version,
+		FromRevision: resource.RevisionNumber(len(revisions)),
+		ToRevision:   cfg.ToRevision,
+		Changes:      resource.Diff(current, target),
+		DryRun:       cfg.DryRun,
+		StatusKept:   statusKept != nil,
+		Message:      resource.Message(fmt.Sprintf("Resource rolled back to revision %d", cfg.ToRevision)),
+	}
+
+	switch {
+	case len(result.Changes) == 0:
+		result.Message = "No changes"
+	case cfg.DryRun:
+		result.Message = resource.Message(fmt.Sprintf("Dry run: would have rolled back to revision %d", cfg.ToRevision))
+	default:
+		updated, err := store.Update(ctx, target)
+		if err != nil {
+			return Result{}, err
+		}
+		result.Version = updated.Version
+	}
+	if statusKept != nil {
+		result.Message += resource.Message(fmt.Sprintf("; status kept as %s: %v", current.Status, statusKept))
+		logger.Warn("Status not rolled back", "resource_id", current.ID, "status", current.Status, "error", statusKept)
+	}
+
+	logger.Info("Resource rollback complete", "resource_id", result.ResourceID, "changes", len(result.Changes))
+	return result, nil
+}
+
+// restore returns current with the mutable fields of snapshot. Identity,
+// namespace, version, kind, creation time, deletion state, status and status
+// history are kept from current; a status change goes through the lifecycle,
+// and when the lifecycle does not allow it the current status stays.
+func restore(current, snapshot resource.Resource) resource.Resource {
+	target := snapshot
+	target.ID = current.ID
+	target.Namespace = current.Namespace
+	target.Version = current.Version
+	target.Kind = current.Kind
+	target.CreatedAt = current.CreatedAt
+	target.DeletedAt = current.DeletedAt
+	target.Status = current.Status
+	target.Transitions = current.Transitions
+	return target
+}
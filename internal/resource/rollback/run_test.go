@@ -0,0 +1,56 @@
+package rollback
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+func TestRunKeepsUnreachableStatus(t *testing.T) {
+	ctx := context.Background()
+	store := resource.NewMemoryStore()
+	prev := openStore
+	openStore = resource.OpenMemory(store)
+	t.Cleanup(func() { openStore = prev })
+
+	res, err := store.Create(ctx, resource.Resource{ID: "res-1", Name: "db", Status: resource.StatusPending})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	res.Description = "primary"
+	if res, err = store.Update(ctx, res); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if err := res.Transition(resource.StatusActive, "test", time.Now().UTC()); err != nil {
+		t.Fatalf("Transition: %v", err)
+	}
+	res.Description = "replica"
+	if _, err = store.Update(ctx, res); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	// Revision 2 is pending, which active cannot go back to.
+	result, err := Run(ctx, slog.New(slog.NewTextHandler(io.Discard, nil)), Config{
+		Ref:        "db",
+		ToRevision: 2,
+		Namespace:  resource.DefaultNamespace,
+		State:      resource.StateDir(t.TempDir()),
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if !result.StatusKept || result.Version != 4 {
+		t.Errorf("Run = %+v, want version 4 with the status kept", result)
+	}
+	got, err := store.Get(ctx, "res-1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Status != resource.StatusActive || got.Description != "primary" {
+		t.Errorf("got status %s, description %q; want %s, %q", got.Status, got.Description, resource.StatusActive, "primary")
+	}
+}
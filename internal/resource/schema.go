@@ -0,0 +1,188 @@
+package resource
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// schemaDirName is the directory within a state directory holding one
+// JSON Schema per kind, named <kind>.json.
+const schemaDirName = "schemas"
+
+// ErrUnknownKind is returned for a kind without a registered schema.
+var ErrUnknownKind = errors.New("unknown resource kind")
+
+// SpecError reports a spec that does not match the schema of its kind.
+type SpecError struct {
+	Kind Kind
+	Err  error
+}
+
+func (e *SpecError) Error() string {
+	return fmt.Sprintf("spec does not match the schema for kind %q: %v", e.Kind, e.Err)
+}
+func (e *SpecError) Unwrap() error { return e.Err }
+
+// ExitCode implements cli.ExitCoder.
+func (e *SpecError) ExitCode() int { return ExitCodeInvalid }
+
+// Schemas is the registry of spec schemas kept in a state directory.
+type Schemas struct {
+	dir string
+}
+
+// OpenSchemas returns the schema registry of a state directory. An empty dir
+// selects DefaultStateDir.
+func OpenSchemas(dir StateDir) (*Schemas, error) {
+	if dir == "" {
+		dir = DefaultStateDir()
+	}
+	return &Schemas{dir: filepath.Join(string(dir), schemaDirName)}, nil
+}
+
+// Register compiles schema and stores it as the schema for kind, replacing
+// any previous one.
+func (s *Schemas) Register(kind Kind, schema []byte) error {
+	if kind == "" {
+		return errors.New("kind is required")
+	}
+	if _, err := compileSchema(kind, schema); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return fmt.Errorf("create schema directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(s.dir, string(kind)+".*")
+	if err != nil {
+		return fmt.Errorf("write schema: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(schema); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write schema: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write schema: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path(kind)); err != nil {
+		return fmt.Errorf("write schema: %w", err)
+	}
+	return nil
+}
+
+// Kinds returns the registered kinds in name order.
+func (s *Schemas) Kinds() ([]Kind, error) {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return []Kind{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read schema directory: %w", err)
+	}
+	kinds := []Kind{}
+	for _, entry := range entries {
+		if name, ok := strings.CutSuffix(entry.Name(), ".json"); ok && !entry.IsDir() {
+			kinds = append(kinds, Kind(name))
+		}
+	}
+	slices.Sort(kinds)
+	return kinds, nil
+}
+
+// Schema returns the registered schema for kind.
+func (s *Schemas) Schema(kind Kind) (json.RawMessage, error) {
+	data, err := os.ReadFile(s.path(kind))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w %q; register a schema for it first", ErrUnknownKind, kind)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read schema: %w", err)
+	}
+	return data, nil
+}
+
+// Validate checks spec against the schema for kind. Resources without a kind
+// accept any spec; any other kind must be registered.
+func (s *Schemas) Validate(kind Kind, spec Spec) error {
+	if kind == "" {
+		return nil
+	}
+	data, err := s.Schema(kind)
+	if err != nil {
+		return err
+	}
+	schema, err := compileSchema(kind, data)
+	if err != nil {
+		return err
+	}
+
+	if spec == nil {
+		spec = Spec{}
+	}
+	doc, err := json.Marshal(spec)
+	if err != nil {
+		return err
+	}
+	instance, err := jsonschema.UnmarshalJSON(bytes.NewReader(doc))
+	if err != nil {
+		return err
+	}
+	if err := schema.Validate(instance); err != nil {
+		var verr *jsonschema.ValidationError
+		if errors.As(err, &verr) {
+			err = errors.New(describe(verr))
+		}
+		return &SpecError{Kind: kind, Err: err}
+	}
+	return nil
+}
+
+func (s *Schemas) path(kind Kind) string {
+	return filepath.Join(s.dir, string(kind)+".json")
+}
+
+func compileSchema(kind Kind, data []byte) (*jsonschema.Schema, error) {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("parse schema for kind %q: %w", kind, err)
+	}
+	url := "mga:///schemas/" + string(kind) + ".json"
+	c := jsonschema.NewCompiler()
+	if err := c.AddResource(url, doc); err != nil {
+		return nil, fmt.Errorf("load schema for kind %q: %w", kind, err)
+	}
+	schema, err := c.Compile(url)
+	if err != nil {
+		return nil, fmt.Errorf("compile schema for kind %q: %w", kind, err)
+	}
+	return schema, nil
+}
+
+// describe flattens a validation error into "/path: message" pairs.
+func describe(err *jsonschema.ValidationError) string {
+	var parts []string
+	for _, unit := range err.BasicOutput().Errors {
+		if unit.Error == nil {
+			continue
+		}
+		location := unit.InstanceLocation
+		if location == "" {
+			location = "/"
+		}
+		parts = append(parts, location+": "+unit.Error.String())
+	}
+	if len(parts) == 0 {
+		return err.Error()
+	}
+	return strings.Join(parts, "; ")
+}
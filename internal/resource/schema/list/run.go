@@ -0,0 +1,57 @@
+// Package list implements listing the registered spec schemas
+package list
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// Schema is a registered kind and its JSON Schema
+type Schema struct {
+	Kind   resource.Kind   `json:"kind"`
+	Schema json.RawMessage `json:"schema"`
+}
+
+// Result holds the registered schemas
+type Result struct {
+	Schemas []Schema `json:"schemas"`
+	Total   int      `json:"total"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (r Result) MarshalJSON() ([]byte, error) {
+	type Alias Result
+	return json.Marshal((Alias)(r))
+}
+
+// openSchemas opens the spec schema registry.
+var openSchemas = resource.OpenSchemas
+
+// Run lists every registered kind with its schema, in kind order
+func Run(ctx context.Context, logger *slog.Logger, cfg Config) (Result, error) {
+	logger.Info("Listing schemas")
+
+	schemas, err := openSchemas(cfg.State)
+	if err != nil {
+		return Result{}, err
+	}
+	kinds, err := schemas.Kinds()
+	if err != nil {
+		return Result{}, err
+	}
+
+	result := Result{Schemas: make([]Schema, 0, len(kinds)), Total: len(kinds)}
+	for _, kind := range kinds {
+		schema, err := schemas.Schema(kind)
+		if err != nil {
+			return Result{}, err
+		}
+		result.Schemas = append(result.Schemas, Schema{Kind: kind, Schema: schema})
+	}
+
+	logger.Info("Schema listing complete", "total", result.Total)
+	return result, nil
+}
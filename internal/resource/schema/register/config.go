@@ -0,0 +1,35 @@
+package register
+
+import (
+	"errors"
+
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/app/log"
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// Config holds configuration for registering a spec schema
+type Config struct {
+	Kind    resource.Kind     // Kind the schema applies to
+	File    app.FilePath      // JSON Schema file
+	State   resource.StateDir // Resource store directory
+	Output  app.FilePath      // Output file path
+	Logging log.Config
+}
+
+func (c Config) OutputFilePath() app.FilePath { return c.Output }
+func (c Config) LoggerConfig() log.Config     { return c.Logging }
+
+// Validate implements app.Validator
+func (c Config) Validate() error {
+	var v app.ValidationError
+	if c.Kind == "" {
+		v.Check("kind", errors.New("is required"))
+	} else {
+		v.Check("kind", c.Kind.Validate())
+	}
+	if c.File == "" {
+		v.Check("file", errors.New("is required"))
+	}
+	return v.Err()
+}
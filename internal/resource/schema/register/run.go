@@ -0,0 +1,89 @@
+// Package register implements registering the spec schema of a resource kind
+package register
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// Result holds the result of registering a schema
+type Result struct {
+	Success bool          `json:"success"`
+	Kind    resource.Kind `json:"kind"`
+	// Resources of the kind whose spec does not match the new schema. They
+	// keep working, but their next spec change must satisfy it.
+	Nonconforming []resource.ID    `json:"nonconforming"`
+	Message       resource.Message `json:"message"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (r Result) MarshalJSON() ([]byte, error) {
+	type Alias Result
+	return json.Marshal((Alias)(r))
+}
+
+// openStore opens the resource store.
+var openStore resource.OpenFunc = resource.Open
+
+// openSchemas opens the spec schema registry.
+var openSchemas = resource.OpenSchemas
+
+// Run stores the schema for cfg.Kind and reports existing resources that do not match it
+func Run(ctx context.Context, logger *slog.Logger, cfg Config) (Result, error) {
+	logger.Info("Registering schema", "kind", cfg.Kind, "file", cfg.File)
+
+	data, err := os.ReadFile(string(cfg.File))
+	if err != nil {
+		return Result{}, fmt.Errorf("read schema file: %w", err)
+	}
+
+	schemas, err := openSchemas(cfg.State)
+	if err != nil {
+		return Result{}, err
+	}
+	if err := schemas.Register(cfg.Kind, data); err != nil {
+		return Result{}, err
+	}
+
+	store, err := openStore(cfg.State)
+	if err != nil {
+		return Result{}, fmt.Errorf("open resource store: %w", err)
+	}
+	all, err := store.List(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+
+	nonconforming := []resource.ID{}
+	for _, res := range all {
+		if res.Kind != cfg.Kind || res.Deleted() {
+			continue
+		}
+		err := schemas.Validate(res.Kind, res.Spec)
+		var specErr *resource.SpecError
+		if errors.As(err, &specErr) {
+			nonconforming = append(nonconforming, res.ID)
+		} else if err != nil {
+			return Result{}, err
+		}
+	}
+
+	result := Result{
+		Success:       true,
+		Kind:          cfg.Kind,
+		Nonconforming: nonconforming,
+		Message:       "Schema registered successfully",
+	}
+	if len(nonconforming) > 0 {
+		result.Message = resource.Message(fmt.Sprintf("Schema registered; %d existing resources do not match it", len(nonconforming)))
+	}
+
+	logger.Info("Schema registration complete", "kind", cfg.Kind, "nonconforming", len(nonconforming))
+	return result, nil
+}
@@ -0,0 +1,279 @@
+package resource
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Query is a full-text search query over resource names, descriptions and
+// tags, e.g. `web "payment api" tag:prod name:db*`.
+type Query string
+
+// SearchField is a field a query term can be restricted to.
+type SearchField string
+
+// Searchable fields, with the weight of a match in each.
+const (
+	SearchName        SearchField = "name"
+	SearchDescription SearchField = "description"
+	SearchTags        SearchField = "tags"
+)
+
+var searchWeights = map[SearchField]float64{
+	SearchName:        3,
+	SearchTags:        2,
+	SearchDescription: 1,
+}
+
+// searchFieldAliases maps the field names accepted in field:value terms.
+var searchFieldAliases = map[string]SearchField{
+	"name":        SearchName,
+	"description": SearchDescription,
+	"desc":        SearchDescription,
+	"tags":        SearchTags,
+	"tag":         SearchTags,
+}
+
+// SearchTerm is a single word, prefix or phrase of a query.
+type SearchTerm struct {
+	Field  SearchField // Empty to match in any field
+	Words  []string    // Lower-cased words; more than one for a phrase
+	Prefix bool        // Whether the last word matches as a prefix
+}
+
+func (t SearchTerm) String() string {
+	s := strings.Join(t.Words, " ")
+	if len(t.Words) > 1 {
+		s = `"` + s + `"`
+	}
+	if t.Prefix {
+		s += "*"
+	}
+	if t.Field != "" {
+		s = string(t.Field) + ":" + s
+	}
+	return s
+}
+
+// Parse splits the query into terms. Terms are separated by spaces; a term
+// in double quotes is a phrase whose words must appear next to each other, a
+// trailing * matches words by prefix, and field:value (or field:"phrase")
+// restricts the term to name, description or tags.
+func (q Query) Parse() ([]SearchTerm, error) {
+	var terms []SearchTerm
+	s := string(q)
+	for i := 0; i < len(s); {
+		if s[i] == ' ' || s[i] == '\t' {
+			i++
+			continue
+		}
+		start := i
+
+		var term SearchTerm
+		if colon := strings.IndexByte(s[i:], ':'); colon > 0 && !strings.ContainsAny(s[i:i+colon], " \t\"") {
+			name := s[i : i+colon]
+			field, ok := searchFieldAliases[strings.ToLower(name)]
+			if !ok {
+				return nil, fmt.Errorf("invalid query at column %d: unknown field %q (valid: name, description, tags)", start+1, name)
+			}
+			term.Field = field
+			i += colon + 1
+		}
+
+		var text string
+		if i < len(s) && s[i] == '"' {
+			end := strings.IndexByte(s[i+1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid query at column %d: unterminated phrase", i+1)
+			}
+			text, i = s[i+1:i+1+end], i+end+2
+			if i < len(s) && s[i] == '*' {
+				term.Prefix, i = true, i+1
+			}
+		} else {
+			end := strings.IndexAny(s[i:], " \t")
+			if end < 0 {
+				end = len(s) - i
+			}
+			text, i = s[i:i+end], i+end
+			if strings.HasSuffix(text, "*") {
+				term.Prefix, text = true, strings.TrimSuffix(text, "*")
+			}
+		}
+
+		term.Words = searchWords(text)
+		if len(term.Words) == 0 {
+			return nil, fmt.Errorf("invalid query at column %d: %q has no words to search for", start+1, s[start:i])
+		}
+		terms = append(terms, term)
+	}
+	if len(terms) == 0 {
+		return nil, errors.New("query is empty")
+	}
+	return terms, nil
+}
+
+// searchWords lower-cases s and splits it into runs of letters and digits.
+func searchWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// searchDocument holds the words of each searchable field of a resource.
+type searchDocument map[SearchField][]string
+
+func newSearchDocument(res Resource) searchDocument {
+	doc := searchDocument{
+		SearchName:        searchWords(string(res.Name)),
+		SearchDescription: searchWords(string(res.Description)),
+	}
+	for _, tag := range res.Tags {
+		doc[SearchTags] = append(doc[SearchTags], searchWords(string(tag))...)
+	}
+	return doc
+}
+
+// count returns how often term occurs in the words of one field.
+func (t SearchTerm) count(words []string) int {
+	n := 0
+	for i := 0; i+len(t.Words) <= len(words); i++ {
+		if t.matchesAt(words, i) {
+			n++
+		}
+	}
+	return n
+}
+
+func (t SearchTerm) matchesAt(words []string, i int) bool {
+	last := len(t.Words) - 1
+	for j, word := range t.Words {
+		switch {
+		case j == last && t.Prefix:
+			if !strings.HasPrefix(words[i+j], word) {
+				return false
+			}
+		case words[i+j] != word:
+			return false
+		}
+	}
+	return true
+}
+
+// fields returns the fields term may match in.
+func (t SearchTerm) fields() []SearchField {
+	if t.Field != "" {
+		return []SearchField{t.Field}
+	}
+	return []SearchField{SearchName, SearchTags, SearchDescription}
+}
+
+// SearchHit is a resource matching every term of a query.
+type SearchHit struct {
+	Resource Resource
+	Score    float64       // Relevance; higher is better
+	Fields   []SearchField // Fields in which any term matched
+}
+
+// Search returns the resources matching every term, most relevant first.
+//
+// Each term contributes its inverse document frequency, so rare words count
+// for more than common ones, times the weighted sum over the fields it
+// matched in (name 3, tags 2, description 1). Repeated occurrences add with
+// diminishing returns, prefix matches count half, and a name that is exactly
+// the query doubles the score.
+func Search(resources []Resource, terms []SearchTerm) []SearchHit {
+	docs := make([]searchDocument, len(resources))
+	for i, res := range resources {
+		docs[i] = newSearchDocument(res)
+	}
+
+	// Documents containing each term, for its inverse document frequency.
+	idf := make([]float64, len(terms))
+	for i, term := range terms {
+		df := 0
+		for _, doc := range docs {
+			if term.scoreIn(doc, nil) > 0 {
+				df++
+			}
+		}
+		idf[i] = math.Log(1 + float64(len(docs))/float64(max(df, 1)))
+	}
+
+	var hits []SearchHit
+	for i, doc := range docs {
+		matched := map[SearchField]bool{}
+		score := 0.0
+		for j, term := range terms {
+			s := term.scoreIn(doc, matched)
+			if s == 0 {
+				score = 0
+				break
+			}
+			score += idf[j] * s
+		}
+		if score == 0 {
+			continue
+		}
+		if exactName(resources[i], terms) {
+			score *= 2
+		}
+
+		hit := SearchHit{Resource: resources[i], Score: math.Round(score*1000) / 1000}
+		for _, field := range []SearchField{SearchName, SearchDescription, SearchTags} {
+			if matched[field] {
+				hit.Fields = append(hit.Fields, field)
+			}
+		}
+		hits = append(hits, hit)
+	}
+
+	slices.SortStableFunc(hits, func(a, b SearchHit) int {
+		if a.Score != b.Score {
+			if a.Score > b.Score {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(string(a.Resource.Name), string(b.Resource.Name))
+	})
+	return hits
+}
+
+// scoreIn returns the weighted score of term in doc, recording the fields it
+// matched in when matched is not nil.
+func (t SearchTerm) scoreIn(doc searchDocument, matched map[SearchField]bool) float64 {
+	score := 0.0
+	for _, field := range t.fields() {
+		n := t.count(doc[field])
+		if n == 0 {
+			continue
+		}
+		if matched != nil {
+			matched[field] = true
+		}
+		s := searchWeights[field] * float64(n) / float64(n+1) * 2 // 1 for a single occurrence
+		if t.Prefix {
+			s /= 2
+		}
+		score += s
+	}
+	return score
+}
+
+// exactName reports whether the resource name consists of exactly the words
+// of the query, in order.
+func exactName(res Resource, terms []SearchTerm) bool {
+	var words []string
+	for _, term := range terms {
+		if term.Prefix || (term.Field != "" && term.Field != SearchName) {
+			return false
+		}
+		words = append(words, term.Words...)
+	}
+	return slices.Equal(words, searchWords(string(res.Name)))
+}
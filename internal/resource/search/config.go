@@ -0,0 +1,31 @@
+package search
+
+import (
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/app/log"
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// Config holds configuration for searching resources
+type Config struct {
+	Query          resource.Query     // Search query
+	Limit          resource.Limit     // Maximum number of hits (0 = unlimited)
+	IncludeDeleted bool               // Include resources in the trash
+	Namespace      resource.Namespace // Namespace of the resources
+	State          resource.StateDir  // Resource store directory
+	Output         app.FilePath       // Output file path
+	Logging        log.Config
+}
+
+// Validate implements app.Validator
+func (c Config) Validate() error {
+	var v app.ValidationError
+	v.Check("namespace", c.Namespace.Validate())
+	if _, err := c.Query.Parse(); err != nil {
+		v.Check("query", err)
+	}
+	return v.Err()
+}
+
+func (c Config) OutputFilePath() app.FilePath { return c.Output }
+func (c Config) LoggerConfig() log.Config     { return c.Logging }
@@ -0,0 +1,110 @@
+// Package search implements full-text search over resources
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// Hit is a resource matching the query, with its relevance score
+type Hit struct {
+	Score       float64                `json:"score"`
+	ID          resource.ID            `json:"id"`
+	Namespace   resource.Namespace     `json:"namespace"`
+	Version     resource.Version       `json:"version"`
+	Name        resource.Name          `json:"name"`
+	Description resource.Description   `json:"description,omitempty"`
+	Status      resource.Status        `json:"status"`
+	Tags        []resource.Tag         `json:"tags,omitempty"`
+	Matched     []resource.SearchField `json:"matched"`
+	DeletedAt   *resource.DeletedAt    `json:"deleted_at,omitempty"`
+}
+
+// Result holds the hits of a search, most relevant first
+type Result struct {
+	Query          resource.Query `json:"query"`
+	Terms          []string       `json:"terms"`
+	Hits           []Hit          `json:"hits"`
+	Total          int            `json:"total"`
+	Limit          resource.Limit `json:"limit"`
+	IncludeDeleted bool           `json:"include_deleted,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (r Result) MarshalJSON() ([]byte, error) {
+	type Alias Result
+	return json.Marshal((Alias)(r))
+}
+
+// openStore opens the resource store.
+var openStore resource.OpenFunc = resource.Open
+
+// Run ranks resources by relevance to the query
+func Run(ctx context.Context, logger *slog.Logger, cfg Config) (Result, error) {
+	logger.Info("Searching resources",
+		"namespace", cfg.Namespace,
+		"query", cfg.Query,
+		"limit", cfg.Limit,
+		"include_deleted", cfg.IncludeDeleted,
+	)
+
+	terms, err := cfg.Query.Parse()
+	if err != nil {
+		return Result{}, err
+	}
+
+	store, err := openStore(cfg.State)
+	if err != nil {
+		return Result{}, fmt.Errorf("open resource store: %w", err)
+	}
+
+	stored, err := store.List(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	stored = resource.InNamespace(stored, cfg.Namespace)
+	candidates := stored[:0]
+	for _, res := range stored {
+		if !res.Deleted() || cfg.IncludeDeleted {
+			candidates = append(candidates, res)
+		}
+	}
+
+	found := resource.Search(candidates, terms)
+	result := Result{
+		Query:          cfg.Query,
+		Terms:          make([]string, len(terms)),
+		Hits:           []Hit{},
+		Total:          len(found),
+		Limit:          cfg.Limit,
+		IncludeDeleted: cfg.IncludeDeleted,
+	}
+	for i, term := range terms {
+		result.Terms[i] = term.String()
+	}
+	if cfg.Limit > 0 && len(found) > int(cfg.Limit) {
+		found = found[:cfg.Limit]
+	}
+	for _, hit := range found {
+		res := hit.Resource
+		result.Hits = append(result.Hits, Hit{
+			Score:       hit.Score,
+			ID:          res.ID,
+			Namespace:   res.Namespace,
+			Version:     res.Version,
+			Name:        res.Name,
+			Description: res.Description,
+			Status:      res.Status,
+			Tags:        res.Tags,
+			Matched:     hit.Fields,
+			DeletedAt:   res.DeletedAt,
+		})
+	}
+
+	logger.Info("Resource search complete", "hits", len(result.Hits), "total", result.Total)
+	return result, nil
+}
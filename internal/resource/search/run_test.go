@@ -0,0 +1,48 @@
+package search
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+func TestRunHits(t *testing.T) {
+	ctx := context.Background()
+	store := resource.NewMemoryStore()
+	prev := openStore
+	openStore = resource.OpenMemory(store)
+	t.Cleanup(func() { openStore = prev })
+
+	if _, err := store.CreateNamespace(ctx, "team-a"); err != nil {
+		t.Fatalf("CreateNamespace: %v", err)
+	}
+	res, err := store.Create(ctx, resource.Resource{ID: "res-1", Name: "db", Namespace: "team-a"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	res.Description = "Primary database"
+	if _, err := store.Update(ctx, res); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if _, err := store.Create(ctx, resource.Resource{ID: "res-2", Name: "db"}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	result, err := Run(ctx, slog.New(slog.NewTextHandler(io.Discard, nil)), Config{
+		Query:     "db",
+		Namespace: "team-a",
+		State:     resource.StateDir(t.TempDir()),
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(result.Hits) != 1 {
+		t.Fatalf("got %d hits, want 1", len(result.Hits))
+	}
+	if hit := result.Hits[0]; hit.ID != "res-1" || hit.Namespace != "team-a" || hit.Version != 2 {
+		t.Errorf("hit = %+v, want res-1 in team-a at version 2", hit)
+	}
+}
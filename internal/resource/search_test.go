@@ -0,0 +1,109 @@
+package resource
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestQueryParse(t *testing.T) {
+	tests := []struct {
+		query Query
+		want  []SearchTerm
+	}{
+		{"web", []SearchTerm{{Words: []string{"web"}}}},
+		{`"Payment API"`, []SearchTerm{{Words: []string{"payment", "api"}}}},
+		{"name:db*", []SearchTerm{{Field: SearchName, Words: []string{"db"}, Prefix: true}}},
+		{`TAG:"prod eu"*`, []SearchTerm{{Field: SearchTags, Words: []string{"prod", "eu"}, Prefix: true}}},
+		{"desc:Hello-World", []SearchTerm{{Field: SearchDescription, Words: []string{"hello", "world"}}}},
+		{"web \t tag:prod", []SearchTerm{
+			{Words: []string{"web"}},
+			{Field: SearchTags, Words: []string{"prod"}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.query), func(t *testing.T) {
+			got, err := tt.query.Parse()
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryParseErrors(t *testing.T) {
+	tests := []struct {
+		query  Query
+		column int // 0 when the error has no position
+		want   string
+	}{
+		{"", 0, "query is empty"},
+		{"   ", 0, "query is empty"},
+		{"color:red", 1, `unknown field "color"`},
+		{`web "payment`, 5, "unterminated phrase"},
+		{"web ***", 5, `"***" has no words`},
+		{"name:", 1, `"name:" has no words`},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.query), func(t *testing.T) {
+			_, err := tt.query.Parse()
+			if err == nil {
+				t.Fatal("Parse succeeded, want an error")
+			}
+			if column := fmt.Sprintf("column %d:", tt.column); tt.column > 0 && !strings.Contains(err.Error(), column) {
+				t.Errorf("error %q is not at %s", err, column)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error %q does not mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	resources := []Resource{
+		{ID: "res-1", Name: "web", Description: "Talks to the db", Tags: []Tag{"frontend"}},
+		{ID: "res-2", Name: "db-backup", Tags: []Tag{"nightly"}},
+		{ID: "res-3", Name: "db", Tags: []Tag{"prod"}},
+	}
+
+	tests := []struct {
+		query Query
+		want  []Name
+	}{
+		// An exact name ranks first, then name matches before descriptions.
+		{"db", []Name{"db", "db-backup", "web"}},
+		{"DB", []Name{"db", "db-backup", "web"}},
+		{"db web", []Name{"web"}},
+		{"back*", []Name{"db-backup"}},
+		{"name:db", []Name{"db", "db-backup"}},
+		{"tag:prod", []Name{"db"}},
+		{`"to the db"`, []Name{"web"}},
+		{`"the to db"`, nil},
+		{"cache", nil},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.query), func(t *testing.T) {
+			terms, err := tt.query.Parse()
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			var got []Name
+			for _, hit := range Search(resources, terms) {
+				got = append(got, hit.Resource.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Search = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	hits := Search(resources, []SearchTerm{{Words: []string{"db"}}})
+	if fields := hits[2].Fields; !reflect.DeepEqual(fields, []SearchField{SearchDescription}) {
+		t.Errorf("web matched in %v, want [description]", fields)
+	}
+}
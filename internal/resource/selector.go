@@ -0,0 +1,272 @@
+package resource
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Selector is a label selector expression, e.g. "env=prod,tier!=cache,team in (a,b)".
+type Selector string
+
+// SelectorOp is the operator of a single selector requirement.
+type SelectorOp string
+
+// Selector operators.
+const (
+	OpEquals       SelectorOp = "="
+	OpNotEquals    SelectorOp = "!="
+	OpIn           SelectorOp = "in"
+	OpNotIn        SelectorOp = "notin"
+	OpExists       SelectorOp = "exists"
+	OpDoesNotExist SelectorOp = "!"
+)
+
+// Requirement is a single condition on a label.
+type Requirement struct {
+	Key    string
+	Op     SelectorOp
+	Values []string
+}
+
+// Matches reports whether labels satisfy the requirement. As in Kubernetes,
+// != and notin also match resources without the key.
+func (r Requirement) Matches(labels Labels) bool {
+	value, ok := labels[r.Key]
+	switch r.Op {
+	case OpEquals:
+		return ok && value == r.Values[0]
+	case OpNotEquals:
+		return !ok || value != r.Values[0]
+	case OpIn:
+		return ok && slices.Contains(r.Values, value)
+	case OpNotIn:
+		return !ok || !slices.Contains(r.Values, value)
+	case OpExists:
+		return ok
+	case OpDoesNotExist:
+		return !ok
+	}
+	return false
+}
+
+// LabelSelector is a parsed Selector. All requirements must match.
+// The zero LabelSelector matches everything.
+type LabelSelector []Requirement
+
+// Matches reports whether labels satisfy every requirement.
+func (s LabelSelector) Matches(labels Labels) bool {
+	for _, r := range s {
+		if !r.Matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// Parse parses the selector. Supported requirements, separated by commas:
+//
+//	key=value  key==value  key!=value
+//	key in (v1,v2)  key notin (v1,v2)
+//	key  !key
+func (s Selector) Parse() (LabelSelector, error) {
+	p := &selectorParser{lexer: selectorLexer{input: string(s)}}
+	p.next()
+
+	var sel LabelSelector
+	if p.tok.kind == tokEOF {
+		return sel, nil
+	}
+	for {
+		req, err := p.requirement()
+		if err != nil {
+			return nil, err
+		}
+		sel = append(sel, req)
+
+		switch p.tok.kind {
+		case tokEOF:
+			return sel, nil
+		case tokComma:
+			p.next()
+		default:
+			return nil, p.errorf("expected ',' or end of selector, found %s", p.tok)
+		}
+	}
+}
+
+type selectorParser struct {
+	lexer selectorLexer
+	tok   token
+}
+
+func (p *selectorParser) next() { p.tok = p.lexer.next() }
+
+func (p *selectorParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("invalid selector at column %d: %s", p.tok.pos+1, fmt.Sprintf(format, args...))
+}
+
+func (p *selectorParser) requirement() (Requirement, error) {
+	if p.tok.kind == tokNot {
+		p.next()
+		key, err := p.key()
+		if err != nil {
+			return Requirement{}, err
+		}
+		return Requirement{Key: key, Op: OpDoesNotExist}, nil
+	}
+
+	key, err := p.key()
+	if err != nil {
+		return Requirement{}, err
+	}
+
+	switch {
+	case p.tok.kind == tokEquals || p.tok.kind == tokDoubleEquals || p.tok.kind == tokNotEquals:
+		op := OpEquals
+		if p.tok.kind == tokNotEquals {
+			op = OpNotEquals
+		}
+		p.next()
+		value, err := p.value()
+		if err != nil {
+			return Requirement{}, err
+		}
+		return Requirement{Key: key, Op: op, Values: []string{value}}, nil
+
+	case p.tok.kind == tokIdent && (p.tok.text == string(OpIn) || p.tok.text == string(OpNotIn)):
+		op := SelectorOp(p.tok.text)
+		p.next()
+		values, err := p.set()
+		if err != nil {
+			return Requirement{}, err
+		}
+		return Requirement{Key: key, Op: op, Values: values}, nil
+
+	case p.tok.kind == tokComma || p.tok.kind == tokEOF:
+		return Requirement{Key: key, Op: OpExists}, nil
+	}
+	return Requirement{}, p.errorf("expected an operator after %q, found %s", key, p.tok)
+}
+
+func (p *selectorParser) key() (string, error) {
+	if p.tok.kind != tokIdent {
+		return "", p.errorf("expected a label key, found %s", p.tok)
+	}
+	key := p.tok.text
+	if err := ValidateLabelKey(key); err != nil {
+		return "", p.errorf("%v", err)
+	}
+	p.next()
+	return key, nil
+}
+
+func (p *selectorParser) value() (string, error) {
+	if p.tok.kind != tokIdent {
+		// An empty value is allowed, as in "key=" or "key in (a,)".
+		return "", nil
+	}
+	value := p.tok.text
+	if err := ValidateLabelValue(value); err != nil {
+		return "", p.errorf("%v", err)
+	}
+	p.next()
+	return value, nil
+}
+
+func (p *selectorParser) set() ([]string, error) {
+	if p.tok.kind != tokOpen {
+		return nil, p.errorf("expected '(', found %s", p.tok)
+	}
+	p.next()
+
+	var values []string
+	for {
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+
+		switch p.tok.kind {
+		case tokComma:
+			p.next()
+		case tokClose:
+			p.next()
+			return values, nil
+		default:
+			return nil, p.errorf("expected ',' or ')', found %s", p.tok)
+		}
+	}
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokComma
+	tokOpen
+	tokClose
+	tokEquals
+	tokDoubleEquals
+	tokNotEquals
+	tokNot
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	if t.kind == tokEOF {
+		return "end of selector"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+type selectorLexer struct {
+	input string
+	pos   int
+}
+
+func (l *selectorLexer) next() token {
+	for l.pos < len(l.input) && l.input[l.pos] == ' ' {
+		l.pos++
+	}
+	start := l.pos
+	if start >= len(l.input) {
+		return token{kind: tokEOF, pos: start}
+	}
+
+	emit := func(kind tokenKind, n int) token {
+		l.pos += n
+		return token{kind: kind, text: l.input[start:l.pos], pos: start}
+	}
+
+	rest := l.input[start:]
+	switch {
+	case strings.HasPrefix(rest, "=="):
+		return emit(tokDoubleEquals, 2)
+	case strings.HasPrefix(rest, "!="):
+		return emit(tokNotEquals, 2)
+	case rest[0] == '=':
+		return emit(tokEquals, 1)
+	case rest[0] == '!':
+		return emit(tokNot, 1)
+	case rest[0] == ',':
+		return emit(tokComma, 1)
+	case rest[0] == '(':
+		return emit(tokOpen, 1)
+	case rest[0] == ')':
+		return emit(tokClose, 1)
+	}
+
+	n := strings.IndexAny(rest, " ,()=!")
+	if n < 0 {
+		n = len(rest)
+	}
+	return emit(tokIdent, n)
+}
@@ -0,0 +1,107 @@
+package resource
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSelectorParse(t *testing.T) {
+	tests := []struct {
+		selector Selector
+		want     LabelSelector
+	}{
+		{"", nil},
+		{"env=prod", LabelSelector{{Key: "env", Op: OpEquals, Values: []string{"prod"}}}},
+		{"env==prod", LabelSelector{{Key: "env", Op: OpEquals, Values: []string{"prod"}}}},
+		{"env!=prod", LabelSelector{{Key: "env", Op: OpNotEquals, Values: []string{"prod"}}}},
+		{"env=", LabelSelector{{Key: "env", Op: OpEquals, Values: []string{""}}}},
+		{"team in (a, b)", LabelSelector{{Key: "team", Op: OpIn, Values: []string{"a", "b"}}}},
+		{"team notin (a,)", LabelSelector{{Key: "team", Op: OpNotIn, Values: []string{"a", ""}}}},
+		{"example.com/owner", LabelSelector{{Key: "example.com/owner", Op: OpExists}}},
+		{"!env", LabelSelector{{Key: "env", Op: OpDoesNotExist}}},
+		{"env=prod, tier!=cache,!legacy", LabelSelector{
+			{Key: "env", Op: OpEquals, Values: []string{"prod"}},
+			{Key: "tier", Op: OpNotEquals, Values: []string{"cache"}},
+			{Key: "legacy", Op: OpDoesNotExist},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.selector), func(t *testing.T) {
+			got, err := tt.selector.Parse()
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectorMatches(t *testing.T) {
+	labels := Labels{"env": "prod", "tier": "web"}
+	tests := []struct {
+		selector Selector
+		want     bool
+	}{
+		{"", true},
+		{"env=prod", true},
+		{"env=dev", false},
+		{"env!=dev", true},
+		{"zone!=eu", true}, // != and notin match a missing key
+		{"zone notin (eu)", true},
+		{"tier in (web,api)", true},
+		{"tier in (api)", false},
+		{"zone in (eu)", false},
+		{"env", true},
+		{"zone", false},
+		{"!zone", true},
+		{"!env", false},
+		{"env=prod,tier=api", false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.selector), func(t *testing.T) {
+			sel, err := tt.selector.Parse()
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if got := sel.Matches(labels); got != tt.want {
+				t.Errorf("Matches = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectorParseErrors(t *testing.T) {
+	tests := []struct {
+		selector Selector
+		column   int
+		want     string
+	}{
+		{"=prod", 1, "expected a label key"},
+		{"env=prod,", 10, "expected a label key"},
+		{"env prod", 5, `expected an operator after "env"`},
+		{"env=prod tier", 10, "expected ',' or end of selector"},
+		{"team in a", 9, "expected '('"},
+		{"team in (a b)", 12, "expected ',' or ')'"},
+		{"team in (a", 11, "expected ',' or ')'"},
+		{"-env=prod", 1, `label key "-env" may only contain`},
+		{"env=-prod", 5, `label value "-prod" may only contain`},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.selector), func(t *testing.T) {
+			_, err := tt.selector.Parse()
+			if err == nil {
+				t.Fatal("Parse succeeded, want an error")
+			}
+			if column := fmt.Sprintf("column %d:", tt.column); !strings.Contains(err.Error(), column) {
+				t.Errorf("error %q is not at %s", err, column)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error %q does not mention %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package resource
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Sortable fields.
+const (
+	SortByID        SortField = "id"
+	SortByName      SortField = "name"
+	SortByStatus    SortField = "status"
+	SortByCreatedAt SortField = "created_at"
+	SortByTagCount  SortField = "tag_count"
+)
+
+// sortComparators maps each sortable field to an ascending comparison.
+var sortComparators = map[SortField]func(a, b Resource) int{
+	SortByID:     func(a, b Resource) int { return cmp.Compare(a.ID, b.ID) },
+	SortByName:   func(a, b Resource) int { return cmp.Compare(a.Name, b.Name) },
+	SortByStatus: func(a, b Resource) int { return cmp.Compare(a.Status, b.Status) },
+	SortByCreatedAt: func(a, b Resource) int {
+		return time.Time(a.CreatedAt).Compare(time.Time(b.CreatedAt))
+	},
+	SortByTagCount: func(a, b Resource) int { return cmp.Compare(len(a.Tags), len(b.Tags)) },
+}
+
+// SortFields returns the names of all sortable fields.
+func SortFields() []SortField {
+	return []SortField{SortByID, SortByName, SortByStatus, SortByCreatedAt, SortByTagCount}
+}
+
+// SortKey is a single field of a multi-key sort.
+type SortKey struct {
+	Field      SortField
+	Descending bool
+}
+
+// ParseSortKeys parses a comma-separated list of fields. A leading "-" sorts
+// that field descending and a leading "+" ascending; unprefixed fields use the
+// ascending argument.
+func ParseSortKeys(fields SortField, ascending bool) ([]SortKey, error) {
+	var keys []SortKey
+	for entry := range strings.SplitSeq(string(fields), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		key := SortKey{Descending: !ascending}
+		switch entry[0] {
+		case '-':
+			key.Descending, entry = true, entry[1:]
+		case '+':
+			key.Descending, entry = false, entry[1:]
+		}
+		key.Field = SortField(entry)
+
+		if _, ok := sortComparators[key.Field]; !ok {
+			return nil, fmt.Errorf("unknown sort field %q (valid: %s)", key.Field, joinFields(SortFields()))
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// String returns the key in the form accepted by ParseSortKeys.
+func (k SortKey) String() string {
+	if k.Descending {
+		return "-" + string(k.Field)
+	}
+	return "+" + string(k.Field)
+}
+
+// Compare returns a comparison over the keys in priority order. Resources equal
+// on every key are ordered by ID so the result is a total order; with no keys
+// resources are ordered by creation time.
+func Compare(keys []SortKey) func(a, b Resource) int {
+	if len(keys) == 0 {
+		keys = []SortKey{{Field: SortByCreatedAt}}
+	}
+	return func(a, b Resource) int {
+		for _, key := range keys {
+			c := sortComparators[key.Field](a, b)
+			if key.Descending {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return cmp.Compare(a.ID, b.ID)
+	}
+}
+
+// Sort orders resources by Compare(keys).
+func Sort(resources []Resource, keys []SortKey) {
+	slices.SortStableFunc(resources, Compare(keys))
+}
+
+func joinFields(fields []SortField) string {
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = string(f)
+	}
+	return strings.Join(names, ", ")
+}
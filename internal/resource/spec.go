@@ -0,0 +1,200 @@
+package resource
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"strings"
+)
+
+// Kind names a type of resource. A kind has a JSON Schema, registered with
+// Schemas, that the spec of every resource of that kind must match.
+type Kind string
+
+// Validate checks that the kind is empty or a DNS label, like a name.
+func (k Kind) Validate() error {
+	if k == "" {
+		return nil
+	}
+	if err := Name(k).Validate(); err != nil {
+		return errors.New(strings.Replace(err.Error(), "name", "kind", 1))
+	}
+	return nil
+}
+
+// Spec holds the custom attributes of a resource as a JSON object.
+type Spec map[string]any
+
+// SpecPath is a dot-separated path into a spec, e.g. "limits.cpu".
+type SpecPath string
+
+// Segments splits the path at dots.
+func (p SpecPath) Segments() ([]string, error) {
+	segments := strings.Split(string(p), ".")
+	for _, segment := range segments {
+		if segment == "" {
+			return nil, fmt.Errorf("spec path %q has an empty segment", p)
+		}
+	}
+	return segments, nil
+}
+
+// SpecAssignment is a path=value pair as given on the command line. Values
+// that parse as JSON keep their type, so "replicas=3" sets a number and
+// "zones=[\"a\"]" an array; anything else is a string.
+type SpecAssignment string
+
+// Split returns the path and decoded value of the assignment.
+func (a SpecAssignment) Split() (SpecPath, any, error) {
+	path, raw, ok := strings.Cut(string(a), "=")
+	if !ok {
+		return "", nil, fmt.Errorf("spec value %q must have the form path=value", a)
+	}
+	if _, err := SpecPath(path).Segments(); err != nil {
+		return "", nil, err
+	}
+
+	var value any
+	dec := json.NewDecoder(strings.NewReader(raw))
+	if err := dec.Decode(&value); err != nil || dec.More() {
+		return SpecPath(path), raw, nil
+	}
+	return SpecPath(path), value, nil
+}
+
+// Validate checks that the assignment is path=value with a valid path.
+func (a SpecAssignment) Validate() error {
+	_, _, err := a.Split()
+	return err
+}
+
+// Set stores value at path, creating intermediate objects as needed.
+func (s Spec) Set(path SpecPath, value any) error {
+	segments, err := path.Segments()
+	if err != nil {
+		return err
+	}
+	obj := map[string]any(s)
+	for i, segment := range segments[:len(segments)-1] {
+		next, ok := obj[segment]
+		if !ok {
+			child := map[string]any{}
+			obj[segment] = child
+			obj = child
+			continue
+		}
+		child, ok := next.(map[string]any)
+		if !ok {
+			return fmt.Errorf("spec path %q: %s is not an object", path, strings.Join(segments[:i+1], "."))
+		}
+		obj = child
+	}
+	obj[segments[len(segments)-1]] = value
+	return nil
+}
+
+// Unset removes the value at path, if present.
+func (s Spec) Unset(path SpecPath) error {
+	segments, err := path.Segments()
+	if err != nil {
+		return err
+	}
+	obj := map[string]any(s)
+	for _, segment := range segments[:len(segments)-1] {
+		child, ok := obj[segment].(map[string]any)
+		if !ok {
+			return nil
+		}
+		obj = child
+	}
+	delete(obj, segments[len(segments)-1])
+	return nil
+}
+
+// Apply returns a copy of s with every assignment set and every path in
+// unset removed. The result is nil if it is empty.
+func (s Spec) Apply(assignments []SpecAssignment, unset []SpecPath) (Spec, error) {
+	out := s.clone()
+	if out == nil {
+		out = Spec{}
+	}
+	for _, a := range assignments {
+		path, value, err := a.Split()
+		if err != nil {
+			return nil, err
+		}
+		if err := out.Set(path, value); err != nil {
+			return nil, err
+		}
+	}
+	for _, path := range unset {
+		if err := out.Unset(path); err != nil {
+			return nil, err
+		}
+	}
+	if len(out) == 0 {
+		return nil, nil
+	}
+	return out, nil
+}
+
+// Equal reports whether both specs hold the same JSON document.
+func (s Spec) Equal(other Spec) bool {
+	if len(s) == 0 || len(other) == 0 {
+		return len(s) == len(other)
+	}
+	a, errA := json.Marshal(s)
+	b, errB := json.Marshal(other)
+	return errA == nil && errB == nil && bytes.Equal(a, b)
+}
+
+// ReadSpecFile reads a spec from a file holding a JSON object.
+func ReadSpecFile(path string) (Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read spec file: %w", err)
+	}
+	return ParseSpec(data)
+}
+
+// ParseSpec decodes a JSON object into a spec.
+func ParseSpec(data []byte) (Spec, error) {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("parse spec: %w", err)
+	}
+	obj, ok := value.(map[string]any)
+	if !ok {
+		return nil, errors.New("parse spec: must be a JSON object")
+	}
+	return Spec(obj), nil
+}
+
+// clone returns a deep copy of the spec.
+func (s Spec) clone() Spec {
+	if s == nil {
+		return nil
+	}
+	return Spec(cloneValue(map[string]any(s)).(map[string]any))
+}
+
+func cloneValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		out := maps.Clone(v)
+		for key, child := range out {
+			out[key] = cloneValue(child)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, child := range v {
+			out[i] = cloneValue(child)
+		}
+		return out
+	}
+	return value
+}
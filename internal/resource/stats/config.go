@@ -0,0 +1,51 @@
+package stats
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/app/log"
+	"github.com/gomatic/modern-go-application/internal/resource"
+	"github.com/gomatic/modern-go-application/internal/resource/list"
+)
+
+// GroupBy names the property resources are counted by.
+type GroupBy string
+
+// Supported groupings.
+const (
+	GroupByStatus     GroupBy = "status"
+	GroupByTag        GroupBy = "tag"
+	GroupByCreatedDay GroupBy = "created_day"
+	GroupByNamespace  GroupBy = "namespace"
+)
+
+// Validate checks that the grouping is supported.
+func (g GroupBy) Validate() error {
+	if !slices.Contains([]GroupBy{GroupByStatus, GroupByTag, GroupByCreatedDay, GroupByNamespace}, g) {
+		return fmt.Errorf("unsupported grouping %q (valid: status, tag, created_day, namespace)", g)
+	}
+	return nil
+}
+
+// Config holds configuration for counting resources
+type Config struct {
+	list.Filter                   // Which resources to count, as for list
+	GroupBy     GroupBy           // Property to count by
+	Percentages bool              // Include each bucket's share of the total
+	State       resource.StateDir // Resource store directory
+	Output      app.FilePath      // Output file path
+	Logging     log.Config
+}
+
+// Validate implements app.Validator
+func (c Config) Validate() error {
+	var v app.ValidationError
+	v.Check("group-by", c.GroupBy.Validate())
+	c.Filter.Check(&v)
+	return v.Err()
+}
+
+func (c Config) OutputFilePath() app.FilePath { return c.Output }
+func (c Config) LoggerConfig() log.Config     { return c.Logging }
@@ -0,0 +1,131 @@
+// Package stats implements counting resources by status, tag or creation day
+package stats
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// Untagged is the tag bucket of resources without tags.
+const Untagged = "(none)"
+
+// Bucket is the number of resources sharing a key
+type Bucket struct {
+	Key     string   `json:"key"`
+	Count   int      `json:"count"`
+	Percent *float64 `json:"percent,omitempty"` // Share of total, with --percentages
+}
+
+// Result holds resource counts per bucket. Status, tag and namespace buckets
+// are ordered by count, largest first; day buckets chronologically. A
+// resource with several tags is counted in each of their buckets, so tag
+// counts can add up to more than the total.
+type Result struct {
+	GroupBy GroupBy  `json:"group_by"`
+	Total   int      `json:"total"`
+	Buckets []Bucket `json:"buckets"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (r Result) MarshalJSON() ([]byte, error) {
+	type Alias Result
+	return json.Marshal((Alias)(r))
+}
+
+// openStore opens the resource store.
+var openStore resource.OpenFunc = resource.Open
+
+// Run counts the resources matching the filter by the configured property
+func Run(ctx context.Context, logger *slog.Logger, cfg Config) (Result, error) {
+	logger.Info("Counting resources",
+		"group_by", cfg.GroupBy,
+		"percentages", cfg.Percentages,
+		"namespace", cfg.Namespace,
+		"all_namespaces", cfg.AllNamespaces,
+		"include", cfg.IncludePatterns,
+		"exclude", cfg.ExcludePatterns,
+		"statuses", cfg.Statuses,
+		"selector", cfg.Selector,
+		"where", cfg.Where,
+		"created_after", cfg.CreatedAfter,
+		"created_before", cfg.CreatedBefore,
+		"updated_since", cfg.UpdatedSince,
+		"include_deleted", cfg.IncludeDeleted,
+	)
+
+	match, err := cfg.Filter.Matcher(time.Now().UTC())
+	if err != nil {
+		return Result{}, err
+	}
+
+	store, err := openStore(cfg.State)
+	if err != nil {
+		return Result{}, fmt.Errorf("open resource store: %w", err)
+	}
+
+	stored, err := store.List(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+
+	result := Result{GroupBy: cfg.GroupBy, Buckets: []Bucket{}}
+	counts := map[string]int{}
+	for _, res := range stored {
+		if !match.Match(res) {
+			continue
+		}
+		result.Total++
+		for _, key := range keys(res, cfg.GroupBy) {
+			counts[key]++
+		}
+	}
+
+	for key, count := range counts {
+		bucket := Bucket{Key: key, Count: count}
+		if cfg.Percentages {
+			percent := math.Round(float64(count)/float64(result.Total)*10000) / 100
+			bucket.Percent = &percent
+		}
+		result.Buckets = append(result.Buckets, bucket)
+	}
+	slices.SortFunc(result.Buckets, func(a, b Bucket) int {
+		if cfg.GroupBy == GroupByCreatedDay {
+			return cmp.Compare(a.Key, b.Key)
+		}
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Key, b.Key))
+	})
+
+	logger.Info("Resource stats complete", "total", result.Total, "buckets", len(result.Buckets))
+	return result, nil
+}
+
+// keys returns the buckets res is counted in
+func keys(res resource.Resource, groupBy GroupBy) []string {
+	switch groupBy {
+	case GroupByTag:
+		if len(res.Tags) == 0 {
+			return []string{Untagged}
+		}
+		keys := make([]string, 0, len(res.Tags))
+		for _, tag := range res.Tags {
+			if !slices.Contains(keys, string(tag)) {
+				keys = append(keys, string(tag))
+			}
+		}
+		return keys
+	case GroupByCreatedDay:
+		return []string{time.Time(res.CreatedAt).UTC().Format(time.DateOnly)}
+	case GroupByNamespace:
+		return []string{string(res.Namespace)}
+	default:
+		return []string{string(res.Status)}
+	}
+}
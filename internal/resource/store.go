@@ -0,0 +1,387 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+)
+
+// Store errors
+var (
+	ErrNotFound      = errors.New("resource not found")
+	ErrAlreadyExists = errors.New("resource already exists")
+	ErrDeleted       = errors.New("resource is deleted")
+	ErrConflict      = errors.New("resource version conflict")
+)
+
+// Process exit codes for store errors.
+const (
+	ExitCodeNotFound      = 3 // No resource matches a lookup
+	ExitCodeAlreadyExists = 4 // A resource with the same identity exists
+	ExitCodeInvalid       = 2 // Input is well-formed but not acceptable; matches app.ExitCodeInvalid
+	ExitCodeConflict      = 5 // The resource changed since it was read
+	ExitCodeHasDependents = 6 // Live resources depend on the resource
+	ExitCodeNotEmpty      = 7 // The namespace still holds resources
+)
+
+// NotFoundError reports that no resource matches a reference.
+type NotFoundError struct {
+	Ref Ref
+}
+
+func (e *NotFoundError) Error() string        { return fmt.Sprintf("resource %q not found", e.Ref) }
+func (e *NotFoundError) Is(target error) bool { return target == ErrNotFound }
+
+// ExitCode implements cli.ExitCoder.
+func (e *NotFoundError) ExitCode() int { return ExitCodeNotFound }
+
+// AlreadyExistsError reports that a resource with the same ID or name exists.
+type AlreadyExistsError struct {
+	ID   ID   // ID of the existing resource
+	Name Name // Name of the existing resource
+}
+
+func (e *AlreadyExistsError) Error() string {
+	return fmt.Sprintf("resource %q already exists as %s", e.Name, e.ID)
+}
+func (e *AlreadyExistsError) Is(target error) bool { return target == ErrAlreadyExists }
+
+// ExitCode implements cli.ExitCoder.
+func (e *AlreadyExistsError) ExitCode() int { return ExitCodeAlreadyExists }
+
+// ConflictError reports that a resource is not at the version a change expected.
+type ConflictError struct {
+	ID       ID      // ID of the resource
+	Expected Version // Version the caller expected
+	Actual   Version // Version in the store
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("resource %s is at version %d, not %d", e.ID, e.Actual, e.Expected)
+}
+func (e *ConflictError) Is(target error) bool { return target == ErrConflict }
+
+// ExitCode implements cli.ExitCoder.
+func (e *ConflictError) ExitCode() int { return ExitCodeConflict }
+
+// CheckVersion returns a ConflictError if res is not at version want.
+// A zero want matches any version.
+func CheckVersion(res Resource, want Version) error {
+	if want != 0 && res.Version != want {
+		return &ConflictError{ID: res.ID, Expected: want, Actual: res.Version}
+	}
+	return nil
+}
+
+// Store persists resources. Every mutation made through Create and Update is
+// recorded as a Revision; Delete removes a resource and its history permanently.
+// Create sets the version to 1. Update fails with a ConflictError unless the
+// resource passed in carries the stored version or a zero version, and
+// increments it. Both set the update time.
+// Writes that would create a dependency cycle, depend on a missing or trashed
+// resource, or remove a resource with dependents are rejected: live ones when
+// moving it to the trash, any when deleting it permanently.
+// Live resources of a namespace have unique names: a write giving a live
+// resource a name another one holds fails with an AlreadyExistsError.
+// Resources are created in DefaultNamespace unless they name another
+// namespace, which must exist; their namespace never changes.
+type Store interface {
+	Create(ctx context.Context, res Resource) (Resource, error)
+	Get(ctx context.Context, id ID) (Resource, error)
+	List(ctx context.Context) ([]Resource, error)
+	Update(ctx context.Context, res Resource) (Resource, error)
+	Delete(ctx context.Context, id ID) error
+	// History returns every revision of a resource, oldest first.
+	History(ctx context.Context, id ID) ([]Revision, error)
+	// Batch runs fn against a view of the store whose changes are committed
+	// together when fn returns nil and discarded when it returns an error.
+	Batch(ctx context.Context, fn func(Store) error) error
+	// Namespaces returns every namespace in name order, including DefaultNamespace.
+	Namespaces(ctx context.Context) ([]NamespaceInfo, error)
+	CreateNamespace(ctx context.Context, ns Namespace) (NamespaceInfo, error)
+	// DeleteNamespace removes an empty namespace other than DefaultNamespace.
+	DeleteNamespace(ctx context.Context, ns Namespace) error
+}
+
+// OpenFunc opens the store rooted at a state directory.
+type OpenFunc func(StateDir) (Store, error)
+
+// state is the in-memory representation shared by the store implementations.
+type state struct {
+	Resources  map[ID]Resource
+	Revisions  map[ID][]Revision
+	Namespaces map[Namespace]NamespaceInfo // Created namespaces; DefaultNamespace is implicit
+}
+
+func newState() *state {
+	return &state{Resources: map[ID]Resource{}, Revisions: map[ID][]Revision{}, Namespaces: map[Namespace]NamespaceInfo{}}
+}
+
+func (s *state) create(res Resource) (Resource, error) {
+	if res.ID == "" {
+		return Resource{}, fmt.Errorf("create resource %q: missing id", res.Name)
+	}
+	if prev, ok := s.Resources[res.ID]; ok {
+		return Resource{}, &AlreadyExistsError{ID: prev.ID, Name: prev.Name}
+	}
+	if res.Namespace == "" {
+		res.Namespace = DefaultNamespace
+	}
+	if !s.hasNamespace(res.Namespace) {
+		return Resource{}, &NamespaceNotFoundError{Namespace: res.Namespace}
+	}
+	if err := s.checkName(res); err != nil {
+		return Resource{}, err
+	}
+	now := time.Now().UTC()
+	if time.Time(res.CreatedAt).IsZero() {
+		res.CreatedAt = CreatedAt(now)
+	}
+	res.UpdatedAt = UpdatedAt(now)
+	if err := s.checkDependencies(res); err != nil {
+		return Resource{}, err
+	}
+	res.Version = 1
+	res = res.clone()
+	s.Resources[res.ID] = res
+	s.record(OperationCreate, nil, res)
+	return res.clone(), nil
+}
+
+func (s *state) get(id ID) (Resource, error) {
+	res, ok := s.Resources[id]
+	if !ok {
+		return Resource{}, &NotFoundError{Ref: Ref(id)}
+	}
+	return res.clone(), nil
+}
+
+// list returns all resources ordered by creation time, then ID.
+func (s *state) list() []Resource {
+	out := make([]Resource, 0, len(s.Resources))
+	for _, id := range slices.Sorted(maps.Keys(s.Resources)) {
+		out = append(out, s.Resources[id].clone())
+	}
+	slices.SortStableFunc(out, func(a, b Resource) int {
+		return time.Time(a.CreatedAt).Compare(time.Time(b.CreatedAt))
+	})
+	return out
+}
+
+func (s *state) update(res Resource) (Resource, error) {
+	prev, ok := s.Resources[res.ID]
+	if !ok {
+		return Resource{}, &NotFoundError{Ref: Ref(res.ID)}
+	}
+	if err := CheckVersion(prev, res.Version); err != nil {
+		return Resource{}, err
+	}
+	res.Namespace = prev.Namespace
+	if err := s.checkName(res); err != nil {
+		return Resource{}, err
+	}
+	if err := s.checkDependencies(res); err != nil {
+		return Resource{}, err
+	}
+	if !prev.Deleted() && res.Deleted() {
+		if err := s.checkDependents(res.ID, false); err != nil {
+			return Resource{}, err
+		}
+	}
+	res.CreatedAt = prev.CreatedAt
+	res.UpdatedAt = UpdatedAt(time.Now().UTC())
+	res.Version = prev.Version + 1
+	res = res.clone()
+	s.Resources[res.ID] = res
+	s.record(operation(prev, res), Diff(prev, res), res)
+	return res.clone(), nil
+}
+
+// checkName rejects a live resource taking a name another live resource of
+// its namespace already has. Resources in the trash keep their names without
+// holding them.
+func (s *state) checkName(res Resource) error {
+	if res.Deleted() {
+		return nil
+	}
+	for _, other := range s.Resources {
+		if other.ID != res.ID && other.Name == res.Name && other.Namespace == res.Namespace && !other.Deleted() {
+			return &AlreadyExistsError{ID: other.ID, Name: other.Name}
+		}
+	}
+	return nil
+}
+
+func (s *state) delete(id ID) error {
+	if _, ok := s.Resources[id]; !ok {
+		return &NotFoundError{Ref: Ref(id)}
+	}
+	// A dependent in the trash could never be restored without this one.
+	if err := s.checkDependents(id, true); err != nil {
+		return err
+	}
+	delete(s.Resources, id)
+	delete(s.Revisions, id)
+	return nil
+}
+
+// batch is a Store over a state that is already loaded and locked, used by
+// Store.Batch implementations.
+type batch struct {
+	state *state
+}
+
+var _ Store = batch{}
+
+func (b batch) Create(ctx context.Context, res Resource) (Resource, error) {
+	if err := ctx.Err(); err != nil {
+		return Resource{}, err
+	}
+	return b.state.create(res)
+}
+
+func (b batch) Get(ctx context.Context, id ID) (Resource, error) {
+	if err := ctx.Err(); err != nil {
+		return Resource{}, err
+	}
+	return b.state.get(id)
+}
+
+func (b batch) List(ctx context.Context) ([]Resource, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return b.state.list(), nil
+}
+
+func (b batch) Update(ctx context.Context, res Resource) (Resource, error) {
+	if err := ctx.Err(); err != nil {
+		return Resource{}, err
+	}
+	return b.state.update(res)
+}
+
+func (b batch) Delete(ctx context.Context, id ID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return b.state.delete(id)
+}
+
+func (b batch) History(ctx context.Context, id ID) ([]Revision, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return b.state.history(id)
+}
+
+// Batch runs fn in the enclosing batch; nested batches are not isolated.
+func (b batch) Batch(ctx context.Context, fn func(Store) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return fn(b)
+}
+
+func (b batch) Namespaces(ctx context.Context) ([]NamespaceInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return b.state.namespaces(), nil
+}
+
+func (b batch) CreateNamespace(ctx context.Context, ns Namespace) (NamespaceInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return NamespaceInfo{}, err
+	}
+	return b.state.createNamespace(ns)
+}
+
+func (b batch) DeleteNamespace(ctx context.Context, ns Namespace) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return b.state.deleteNamespace(ns)
+}
+
+// Find returns the resource in namespace ns whose ID or name equals ref. IDs
+// take precedence over names, and a live resource over those in the trash
+// with the same name. An empty ns searches every namespace.
+func Find(ctx context.Context, store Store, ns Namespace, ref Ref) (Resource, error) {
+	res, err := store.Get(ctx, ID(ref))
+	if err == nil && ns != "" && res.Namespace != ns {
+		err = &NotFoundError{Ref: ref}
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return res, err
+	}
+
+	all, err := store.List(ctx)
+	if err != nil {
+		return Resource{}, err
+	}
+
+	var live, trashed []Resource
+	for _, res := range InNamespace(all, ns) {
+		switch {
+		case res.Name != Name(ref):
+		case res.Deleted():
+			trashed = append(trashed, res)
+		default:
+			live = append(live, res)
+		}
+	}
+
+	matches := live
+	if len(matches) == 0 {
+		matches = trashed
+	}
+	switch len(matches) {
+	case 0:
+		return Resource{}, &NotFoundError{Ref: ref}
+	case 1:
+		return matches[0], nil
+	default:
+		return Resource{}, fmt.Errorf("name %q matches %d resources; use an ID", ref, len(matches))
+	}
+}
+
+// CheckName fails with an AlreadyExistsError if a live resource of res's
+// namespace other than res already uses its name.
+func CheckName(ctx context.Context, store Store, res Resource) error {
+	all, err := store.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, other := range InNamespace(all, res.Namespace) {
+		if other.ID != res.ID && other.Name == res.Name && !other.Deleted() {
+			return &AlreadyExistsError{ID: other.ID, Name: other.Name}
+		}
+	}
+	return nil
+}
+
+// clone returns a copy of the resource that shares no slices with the
+// original. Missing tags become an empty list, so they serialize as [].
+func (r Resource) clone() Resource {
+	r.Tags = slices.Clone(r.Tags)
+	if r.Tags == nil {
+		r.Tags = []Tag{}
+	}
+	r.Labels = maps.Clone(r.Labels)
+	r.Spec = r.Spec.clone()
+	r.DependsOn = slices.Clone(r.DependsOn)
+	r.Transitions = slices.Clone(r.Transitions)
+	if r.ExpiresAt != nil {
+		expiresAt := *r.ExpiresAt
+		r.ExpiresAt = &expiresAt
+	}
+	if r.DeletedAt != nil {
+		deletedAt := *r.DeletedAt
+		r.DeletedAt = &deletedAt
+	}
+	return r
+}
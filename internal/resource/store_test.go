@@ -0,0 +1,252 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// stores returns a fresh instance of every Store implementation, so that each
+// test checks the same contract against all of them.
+func stores(t *testing.T) map[string]Store {
+	t.Helper()
+	file, err := OpenFile(StateDir(t.TempDir()))
+	if err != nil {
+		t.Fatalf("OpenFile: %v", err)
+	}
+	return map[string]Store{
+		"memory": NewMemoryStore(),
+		"file":   file,
+	}
+}
+
+func mustCreate(t *testing.T, store Store, res Resource) Resource {
+	t.Helper()
+	created, err := store.Create(context.Background(), res)
+	if err != nil {
+		t.Fatalf("Create(%s): %v", res.ID, err)
+	}
+	return created
+}
+
+func TestStoreVersions(t *testing.T) {
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			created := mustCreate(t, store, Resource{ID: "res-1", Name: "db"})
+			if created.Version != 1 {
+				t.Errorf("created version = %d, want 1", created.Version)
+			}
+			if created.Namespace != DefaultNamespace {
+				t.Errorf("created namespace = %q, want %q", created.Namespace, DefaultNamespace)
+			}
+			if created.Tags == nil {
+				t.Error("created tags are nil, want empty")
+			}
+
+			created.Description = "first"
+			updated, err := store.Update(ctx, created)
+			if err != nil {
+				t.Fatalf("Update: %v", err)
+			}
+			if updated.Version != 2 {
+				t.Errorf("updated version = %d, want 2", updated.Version)
+			}
+			if !time.Time(updated.CreatedAt).Equal(time.Time(created.CreatedAt)) {
+				t.Errorf("update changed the creation time")
+			}
+
+			// A stale version conflicts; a zero version matches any.
+			created.Description = "stale"
+			if _, err := store.Update(ctx, created); !errors.Is(err, ErrConflict) {
+				t.Errorf("stale Update error = %v, want %v", err, ErrConflict)
+			}
+			updated.Version = 0
+			updated.Description = "any"
+			if got, err := store.Update(ctx, updated); err != nil || got.Version != 3 {
+				t.Errorf("unversioned Update = version %d, %v; want 3, nil", got.Version, err)
+			}
+
+			revisions, err := store.History(ctx, "res-1")
+			if err != nil {
+				t.Fatalf("History: %v", err)
+			}
+			if len(revisions) != 3 {
+				t.Errorf("got %d revisions, want 3", len(revisions))
+			}
+		})
+	}
+}
+
+func TestStoreIdentity(t *testing.T) {
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			mustCreate(t, store, Resource{ID: "res-1", Name: "db"})
+
+			tests := []struct {
+				name string
+				res  Resource
+				want error
+			}{
+				{"missing id", Resource{Name: "web"}, nil},
+				{"duplicate id", Resource{ID: "res-1", Name: "web"}, ErrAlreadyExists},
+				{"missing namespace", Resource{ID: "res-2", Name: "web", Namespace: "team-a"}, ErrNotFound},
+			}
+			for _, tt := range tests {
+				_, err := store.Create(ctx, tt.res)
+				switch {
+				case err == nil:
+					t.Errorf("%s: Create succeeded, want an error", tt.name)
+				case tt.want != nil && !errors.Is(err, tt.want):
+					t.Errorf("%s: Create error = %v, want %v", tt.name, err, tt.want)
+				}
+			}
+
+			if _, err := store.Get(ctx, "res-missing"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Get error = %v, want %v", err, ErrNotFound)
+			}
+			if _, err := store.Update(ctx, Resource{ID: "res-missing"}); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Update error = %v, want %v", err, ErrNotFound)
+			}
+			if err := store.Delete(ctx, "res-missing"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Delete error = %v, want %v", err, ErrNotFound)
+			}
+		})
+	}
+}
+
+func TestStoreNames(t *testing.T) {
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			if _, err := store.CreateNamespace(ctx, "team-a"); err != nil {
+				t.Fatalf("CreateNamespace: %v", err)
+			}
+			db := mustCreate(t, store, Resource{ID: "res-db", Name: "db"})
+			web := mustCreate(t, store, Resource{ID: "res-web", Name: "web"})
+
+			if _, err := store.Create(ctx, Resource{ID: "res-2", Name: "db"}); !errors.Is(err, ErrAlreadyExists) {
+				t.Errorf("Create on a taken name: error = %v, want %v", err, ErrAlreadyExists)
+			}
+			mustCreate(t, store, Resource{ID: "res-a", Name: "db", Namespace: "team-a"})
+
+			web.Name = "db"
+			if _, err := store.Update(ctx, web); !errors.Is(err, ErrAlreadyExists) {
+				t.Errorf("renaming onto a taken name: error = %v, want %v", err, ErrAlreadyExists)
+			}
+
+			// A name in the trash is free to take, but a resource cannot come
+			// back from the trash under a name taken meanwhile.
+			deletedAt := DeletedAt(time.Now().UTC())
+			db.DeletedAt = &deletedAt
+			trashed, err := store.Update(ctx, db)
+			if err != nil {
+				t.Fatalf("trashing db: %v", err)
+			}
+			mustCreate(t, store, Resource{ID: "res-db2", Name: "db"})
+			trashed.DeletedAt = nil
+			if _, err := store.Update(ctx, trashed); !errors.Is(err, ErrAlreadyExists) {
+				t.Errorf("restoring onto a taken name: error = %v, want %v", err, ErrAlreadyExists)
+			}
+		})
+	}
+}
+
+func TestStoreDependencies(t *testing.T) {
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			db := mustCreate(t, store, Resource{ID: "res-db", Name: "db"})
+			web := mustCreate(t, store, Resource{ID: "res-web", Name: "web", DependsOn: []ID{"res-db"}})
+
+			if _, err := store.Create(ctx, Resource{ID: "res-x", Name: "x", DependsOn: []ID{"res-missing"}}); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Create on a missing dependency: error = %v, want %v", err, ErrNotFound)
+			}
+			if _, err := store.Create(ctx, Resource{ID: "res-x", Name: "x", DependsOn: []ID{"res-x"}}); !errors.Is(err, ErrCycle) {
+				t.Errorf("Create depending on itself: error = %v, want %v", err, ErrCycle)
+			}
+
+			db.DependsOn = []ID{"res-web"}
+			if _, err := store.Update(ctx, db); !errors.Is(err, ErrCycle) {
+				t.Errorf("Update closing a cycle: error = %v, want %v", err, ErrCycle)
+			}
+
+			// A live dependent keeps db out of the trash and in the store.
+			trashed := db
+			trashed.DependsOn = nil
+			deletedAt := DeletedAt(time.Now().UTC())
+			trashed.DeletedAt = &deletedAt
+			if _, err := store.Update(ctx, trashed); !errors.Is(err, ErrHasDependents) {
+				t.Errorf("trashing a dependency: error = %v, want %v", err, ErrHasDependents)
+			}
+			if err := store.Delete(ctx, "res-db"); !errors.Is(err, ErrHasDependents) {
+				t.Errorf("deleting a dependency: error = %v, want %v", err, ErrHasDependents)
+			}
+
+			// A dependent in the trash still blocks a permanent delete, but
+			// not a move to the trash.
+			web.DeletedAt = &deletedAt
+			if _, err := store.Update(ctx, web); err != nil {
+				t.Fatalf("trashing the dependent: %v", err)
+			}
+			if err := store.Delete(ctx, "res-db"); !errors.Is(err, ErrHasDependents) {
+				t.Errorf("deleting under a trashed dependent: error = %v, want %v", err, ErrHasDependents)
+			}
+			if _, err := store.Update(ctx, trashed); err != nil {
+				t.Errorf("trashing under a trashed dependent: %v", err)
+			}
+
+			// Nothing may come to depend on a resource in the trash.
+			if _, err := store.Create(ctx, Resource{ID: "res-y", Name: "y", DependsOn: []ID{"res-db"}}); !errors.Is(err, ErrDeleted) {
+				t.Errorf("Create on a trashed dependency: error = %v, want %v", err, ErrDeleted)
+			}
+
+			if err := store.Delete(ctx, "res-web"); err != nil {
+				t.Fatalf("Delete dependent: %v", err)
+			}
+			if err := store.Delete(ctx, "res-db"); err != nil {
+				t.Errorf("Delete without dependents: %v", err)
+			}
+			if _, err := store.History(ctx, "res-db"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("History after Delete: error = %v, want %v", err, ErrNotFound)
+			}
+		})
+	}
+}
+
+func TestStoreBatch(t *testing.T) {
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			fail := errors.New("fail")
+			err := store.Batch(ctx, func(tx Store) error {
+				if _, err := tx.Create(ctx, Resource{ID: "res-1", Name: "db"}); err != nil {
+					return err
+				}
+				return fail
+			})
+			if !errors.Is(err, fail) {
+				t.Fatalf("Batch error = %v, want %v", err, fail)
+			}
+			if all, _ := store.List(ctx); len(all) != 0 {
+				t.Errorf("failed batch left %d resources", len(all))
+			}
+
+			err = store.Batch(ctx, func(tx Store) error {
+				if _, err := tx.Create(ctx, Resource{ID: "res-1", Name: "db"}); err != nil {
+					return err
+				}
+				_, err := tx.Create(ctx, Resource{ID: "res-2", Name: "web", DependsOn: []ID{"res-1"}})
+				return err
+			})
+			if err != nil {
+				t.Fatalf("Batch: %v", err)
+			}
+			if all, _ := store.List(ctx); len(all) != 2 {
+				t.Errorf("batch left %d resources, want 2", len(all))
+			}
+		})
+	}
+}
@@ -0,0 +1,395 @@
+package resource
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// templateDirName is the directory within a state directory holding resource
+// templates, named <name>.json.
+const templateDirName = "templates"
+
+// ErrUnknownTemplate is returned for a template that is neither a file nor in
+// the templates directory.
+var ErrUnknownTemplate = errors.New("unknown template")
+
+// TemplateError reports a template that cannot be read or rendered.
+type TemplateError struct {
+	Template string
+	Err      error
+}
+
+func (e *TemplateError) Error() string {
+	return fmt.Sprintf("template %q: %v", e.Template, e.Err)
+}
+func (e *TemplateError) Unwrap() error { return e.Err }
+
+// ExitCode implements cli.ExitCoder.
+func (e *TemplateError) ExitCode() int { return ExitCodeInvalid }
+
+// TemplateRef names a template: a path to a JSON file, or the name of a
+// template in the templates directory, with or without ".json".
+type TemplateRef string
+
+// TemplateVar is a name=value template variable as given on the command line.
+type TemplateVar string
+
+// Split returns the name and value of the variable.
+func (v TemplateVar) Split() (string, string, error) {
+	name, value, ok := strings.Cut(string(v), "=")
+	if !ok || name == "" {
+		return "", "", fmt.Errorf("template variable %q must have the form name=value", v)
+	}
+	return name, value, nil
+}
+
+// Validate checks that the variable is name=value.
+func (v TemplateVar) Validate() error {
+	_, _, err := v.Split()
+	return err
+}
+
+// Template holds default values for creating a resource. Every string in it,
+// including those nested in the spec, may hold {{ .var }} placeholders;
+// Vars gives default values for variables not set when rendering.
+type Template struct {
+	Name        string            `json:"name,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Kind        string            `json:"kind,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Enabled     *bool             `json:"enabled,omitempty"`
+	Spec        Spec              `json:"spec,omitempty"`
+	Vars        map[string]string `json:"vars,omitempty"`
+}
+
+// ParseTemplate decodes a template from JSON.
+func ParseTemplate(data []byte) (*Template, error) {
+	dec := json.NewDecoder(strings.NewReader(string(data)))
+	dec.DisallowUnknownFields()
+	var t Template
+	if err := dec.Decode(&t); err != nil {
+		return nil, fmt.Errorf("parse template: %w", err)
+	}
+	return &t, nil
+}
+
+// templateFuncs are the functions available to placeholders. json passes its
+// argument through; it marks a spec string whose value is decoded as JSON.
+var templateFuncs = template.FuncMap{
+	"json": func(s string) string { return s },
+}
+
+// Variables returns the names of the variables the template refers to, in
+// name order.
+func (t *Template) Variables() ([]string, error) {
+	names := map[string]bool{}
+	collect := func(s string) (string, error) {
+		trees, err := parse.Parse("template", s, "", "", templateFuncs)
+		if err != nil {
+			return "", err
+		}
+		for _, tree := range trees {
+			collectFields(tree.Root, names)
+		}
+		return s, nil
+	}
+	if err := t.walk(collect); err != nil {
+		return nil, err
+	}
+	if err := walkSpec(t.Spec, collect); err != nil {
+		return nil, err
+	}
+	return slices.Sorted(maps.Keys(names)), nil
+}
+
+// Render returns a copy of the template with its placeholders replaced by
+// vars, falling back to the defaults in Vars. A variable without a value is
+// an error. Spec strings stay strings, except that a spec string holding
+// nothing but a placeholder piped through json, such as "{{ json .replicas }}"
+// or "{{ .replicas | json }}", is replaced by the JSON value it renders to.
+func (t *Template) Render(vars map[string]string) (*Template, error) {
+	data := maps.Clone(t.Vars)
+	if data == nil {
+		data = map[string]string{}
+	}
+	maps.Copy(data, vars)
+
+	names, err := t.Variables()
+	if err != nil {
+		return nil, err
+	}
+	var missing []string
+	for _, name := range names {
+		if _, ok := data[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("no value for %s; set with --var name=value", strings.Join(missing, ", "))
+	}
+
+	rendered := *t
+	rendered.Tags = slices.Clone(t.Tags)
+	rendered.Labels = maps.Clone(t.Labels)
+	err = rendered.walk(func(s string) (string, error) {
+		return renderString(s, data)
+	})
+	if err != nil {
+		return nil, err
+	}
+	spec, err := renderSpec(t.Spec, data)
+	if err != nil {
+		return nil, err
+	}
+	rendered.Spec = spec
+	rendered.Vars = nil
+	return &rendered, nil
+}
+
+// walk replaces every string of the template outside the spec with the
+// result of fn.
+func (t *Template) walk(fn func(string) (string, error)) error {
+	for _, s := range []*string{&t.Name, &t.Description, &t.Kind} {
+		var err error
+		if *s, err = fn(*s); err != nil {
+			return err
+		}
+	}
+	for i, tag := range t.Tags {
+		var err error
+		if t.Tags[i], err = fn(tag); err != nil {
+			return err
+		}
+	}
+	for key, value := range t.Labels {
+		rendered, err := fn(value)
+		if err != nil {
+			return err
+		}
+		t.Labels[key] = rendered
+	}
+	return nil
+}
+
+// walkSpec calls fn on every string in value.
+func walkSpec(value any, fn func(string) (string, error)) error {
+	switch v := value.(type) {
+	case Spec:
+		return walkSpec(map[string]any(v), fn)
+	case map[string]any:
+		for _, child := range v {
+			if err := walkSpec(child, fn); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for _, child := range v {
+			if err := walkSpec(child, fn); err != nil {
+				return err
+			}
+		}
+	case string:
+		_, err := fn(v)
+		return err
+	}
+	return nil
+}
+
+// renderSpec returns a copy of spec with its strings rendered.
+func renderSpec(spec Spec, data map[string]string) (Spec, error) {
+	if spec == nil {
+		return nil, nil
+	}
+	value, err := renderValue(map[string]any(spec), data)
+	if err != nil {
+		return nil, err
+	}
+	return Spec(value.(map[string]any)), nil
+}
+
+func renderValue(value any, data map[string]string) (any, error) {
+	switch v := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, child := range v {
+			rendered, err := renderValue(child, data)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = rendered
+		}
+		return out, nil
+	case []any:
+		out := make([]any, len(v))
+		for i, child := range v {
+			rendered, err := renderValue(child, data)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = rendered
+		}
+		return out, nil
+	case string:
+		rendered, err := renderString(v, data)
+		if err != nil || !jsonPlaceholder(v) {
+			return rendered, err
+		}
+		var typed any
+		dec := json.NewDecoder(strings.NewReader(rendered))
+		if err := dec.Decode(&typed); err != nil || dec.More() {
+			return nil, fmt.Errorf("%s renders to %q, which is not JSON", v, rendered)
+		}
+		return typed, nil
+	}
+	return value, nil
+}
+
+// jsonPlaceholder reports whether s is a single placeholder whose pipeline
+// ends in json.
+func jsonPlaceholder(s string) bool {
+	trees, err := parse.Parse("spec", s, "", "", templateFuncs)
+	if err != nil {
+		return false
+	}
+	nodes := trees["spec"].Root.Nodes
+	if len(nodes) != 1 {
+		return false
+	}
+	action, ok := nodes[0].(*parse.ActionNode)
+	if !ok || len(action.Pipe.Decl) > 0 {
+		return false
+	}
+	last := action.Pipe.Cmds[len(action.Pipe.Cmds)-1]
+	ident, ok := last.Args[0].(*parse.IdentifierNode)
+	return ok && ident.Ident == "json"
+}
+
+func renderString(s string, data map[string]string) (string, error) {
+	if !strings.Contains(s, "{{") {
+		return s, nil
+	}
+	tmpl, err := template.New("").Funcs(templateFuncs).Option("missingkey=error").Parse(s)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// collectFields records the names of the .field references under node.
+func collectFields(node parse.Node, names map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collectFields(child, names)
+		}
+	case *parse.ActionNode:
+		collectFields(n.Pipe, names)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			for _, arg := range cmd.Args {
+				collectFields(arg, names)
+			}
+		}
+	case *parse.FieldNode:
+		names[n.Ident[0]] = true
+	case *parse.IfNode:
+		collectBranch(&n.BranchNode, names)
+	case *parse.RangeNode:
+		collectBranch(&n.BranchNode, names)
+	case *parse.WithNode:
+		collectBranch(&n.BranchNode, names)
+	}
+}
+
+func collectBranch(n *parse.BranchNode, names map[string]bool) {
+	collectFields(n.Pipe, names)
+	collectFields(n.List, names)
+	collectFields(n.ElseList, names)
+}
+
+// Templates is the directory of resource templates kept in a state directory.
+type Templates struct {
+	dir string
+}
+
+// OpenTemplates returns the templates directory of a state directory. An
+// empty dir selects DefaultStateDir.
+func OpenTemplates(dir StateDir) (*Templates, error) {
+	if dir == "" {
+		dir = DefaultStateDir()
+	}
+	return &Templates{dir: filepath.Join(string(dir), templateDirName)}, nil
+}
+
+// Dir returns the path of the templates directory.
+func (t *Templates) Dir() string { return t.dir }
+
+// Names returns the names of the templates in the directory, in name order.
+func (t *Templates) Names() ([]string, error) {
+	entries, err := os.ReadDir(t.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read template directory: %w", err)
+	}
+	names := []string{}
+	for _, entry := range entries {
+		if name, ok := strings.CutSuffix(entry.Name(), ".json"); ok && !entry.IsDir() {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// Load reads the template ref refers to. A ref naming an existing file is
+// read from there; anything else is looked up in the templates directory.
+func (t *Templates) Load(ref TemplateRef) (*Template, error) {
+	if info, err := os.Stat(string(ref)); err == nil && !info.IsDir() {
+		return readTemplate(ref, string(ref))
+	}
+	if strings.ContainsRune(string(ref), filepath.Separator) {
+		return nil, &TemplateError{Template: string(ref), Err: fmt.Errorf("%w: no such file", ErrUnknownTemplate)}
+	}
+	return t.Get(strings.TrimSuffix(string(ref), ".json"))
+}
+
+// Get reads the template called name from the templates directory.
+func (t *Templates) Get(name string) (*Template, error) {
+	return readTemplate(TemplateRef(name), filepath.Join(t.dir, name+".json"))
+}
+
+func readTemplate(ref TemplateRef, path string) (*Template, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, &TemplateError{Template: string(ref), Err: fmt.Errorf("%w: not a file or a template in %s", ErrUnknownTemplate, filepath.Dir(path))}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read template: %w", err)
+	}
+	tmpl, err := ParseTemplate(data)
+	if err != nil {
+		return nil, &TemplateError{Template: string(ref), Err: err}
+	}
+	return tmpl, nil
+}
@@ -0,0 +1,75 @@
+// Package list implements listing the resource templates
+package list
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// Template describes a template in the templates directory
+type Template struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Kind        string            `json:"kind,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	Enabled     *bool             `json:"enabled,omitempty"`
+	Variables   []string          `json:"variables"`
+	Defaults    map[string]string `json:"defaults,omitempty"`
+}
+
+// Result holds the templates found
+type Result struct {
+	Dir       string     `json:"dir"`
+	Templates []Template `json:"templates"`
+	Total     int        `json:"total"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (r Result) MarshalJSON() ([]byte, error) {
+	type Alias Result
+	return json.Marshal((Alias)(r))
+}
+
+// openTemplates opens the templates directory.
+var openTemplates = resource.OpenTemplates
+
+// Run lists every template with the variables it takes, in name order
+func Run(ctx context.Context, logger *slog.Logger, cfg Config) (Result, error) {
+	logger.Info("Listing templates")
+
+	templates, err := openTemplates(cfg.State)
+	if err != nil {
+		return Result{}, err
+	}
+	names, err := templates.Names()
+	if err != nil {
+		return Result{}, err
+	}
+
+	result := Result{Dir: templates.Dir(), Templates: make([]Template, 0, len(names)), Total: len(names)}
+	for _, name := range names {
+		tmpl, err := templates.Get(name)
+		if err != nil {
+			return Result{}, err
+		}
+		variables, err := tmpl.Variables()
+		if err != nil {
+			return Result{}, &resource.TemplateError{Template: name, Err: err}
+		}
+		result.Templates = append(result.Templates, Template{
+			Name:        name,
+			Description: tmpl.Description,
+			Kind:        tmpl.Kind,
+			Tags:        tmpl.Tags,
+			Enabled:     tmpl.Enabled,
+			Variables:   variables,
+			Defaults:    tmpl.Vars,
+		})
+	}
+
+	logger.Info("Template listing complete", "total", result.Total)
+	return result, nil
+}
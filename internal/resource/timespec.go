@@ -0,0 +1,69 @@
+package resource
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeSpec is an absolute time or a duration before now, e.g. "2025-01-01",
+// "2025-01-01T12:00:00Z", "24h" or "7d".
+type TimeSpec string
+
+// Validate checks that the time can be resolved.
+func (t TimeSpec) Validate() error {
+	_, err := t.Resolve(time.Now())
+	return err
+}
+
+// Resolve returns the time t stands for. Dates are midnight UTC and durations,
+// which may use d (24h) and w (7d) besides the units of time.ParseDuration,
+// count back from now. The empty TimeSpec resolves to the zero time.
+func (t TimeSpec) Resolve(now time.Time) (time.Time, error) {
+	s := strings.TrimSpace(string(t))
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if at, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return at, nil
+	}
+	if at, err := time.Parse(time.DateOnly, s); err == nil {
+		return at, nil
+	}
+	if d, err := parseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("%q is not an RFC 3339 time, a date (2006-01-02) or a duration (24h, 7d)", s)
+}
+
+// parseDuration is time.ParseDuration with leading day and week components.
+func parseDuration(s string) (time.Duration, error) {
+	var total time.Duration
+	for {
+		n := strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' })
+		if n <= 0 || (s[n] != 'd' && s[n] != 'w') {
+			break
+		}
+		count, err := strconv.Atoi(s[:n])
+		if err != nil {
+			return 0, err
+		}
+		unit := 24 * time.Hour
+		if s[n] == 'w' {
+			unit *= 7
+		}
+		total += time.Duration(count) * unit
+		if s = s[n+1:]; s == "" {
+			return total, nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("duration %q is negative", s)
+	}
+	return total + d, nil
+}
@@ -0,0 +1,357 @@
+package resource
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format is a bulk import/export encoding.
+type Format string
+
+// Supported bulk formats.
+const (
+	FormatJSON   Format = "json"
+	FormatNDJSON Format = "ndjson"
+	FormatCSV    Format = "csv"
+)
+
+// Formats returns all supported bulk formats.
+func Formats() []Format {
+	return []Format{FormatNDJSON, FormatJSON, FormatCSV}
+}
+
+// Validate checks that the format is supported.
+func (f Format) Validate() error {
+	if !slices.Contains(Formats(), f) {
+		return fmt.Errorf("unsupported format %q (valid: ndjson, json, csv)", f)
+	}
+	return nil
+}
+
+// FormatForPath guesses the format from a file extension, defaulting to NDJSON.
+func FormatForPath(path string) Format {
+	switch {
+	case strings.HasSuffix(path, ".csv"):
+		return FormatCSV
+	case strings.HasSuffix(path, ".json"):
+		return FormatJSON
+	default:
+		return FormatNDJSON
+	}
+}
+
+// Row is the interchange representation of a resource used by import and export.
+// On import, a non-zero Version is the version an existing resource must be at
+// to be updated.
+type Row struct {
+	ID          ID          `json:"id,omitempty"`
+	Version     Version     `json:"version,omitempty"`
+	Name        Name        `json:"name"`
+	Kind        Kind        `json:"kind,omitempty"`
+	Description Description `json:"description,omitempty"`
+	Status      Status      `json:"status,omitempty"`
+	Tags        []Tag       `json:"tags,omitempty"`
+	Labels      Labels      `json:"labels,omitempty"`
+	Spec        Spec        `json:"spec,omitempty"`
+	DependsOn   []ID        `json:"depends_on,omitempty"`
+	Enabled     bool        `json:"enabled"`
+	CreatedAt   *time.Time  `json:"created_at,omitempty"`
+	ExpiresAt   *time.Time  `json:"expires_at,omitempty"`
+}
+
+// NewRow converts a resource into a Row.
+func NewRow(res Resource) Row {
+	createdAt := time.Time(res.CreatedAt)
+	return Row{
+		ID:          res.ID,
+		Version:     res.Version,
+		Name:        res.Name,
+		Kind:        res.Kind,
+		Description: res.Description,
+		Status:      res.Status,
+		Tags:        slices.Clone(res.Tags),
+		Labels:      maps.Clone(res.Labels),
+		Spec:        res.Spec.clone(),
+		DependsOn:   slices.Clone(res.DependsOn),
+		Enabled:     res.Enabled,
+		CreatedAt:   &createdAt,
+		ExpiresAt:   (*time.Time)(res.ExpiresAt),
+	}
+}
+
+// csvHeader lists the CSV columns in export order.
+var csvHeader = []string{"id", "version", "name", "kind", "description", "status", "tags", "labels", "spec", "depends_on", "enabled", "created_at", "expires_at"}
+
+// EncodeRows writes rows in the given format. In CSV, tags, labels and
+// dependencies are comma-separated within their cells and the spec is a JSON
+// object.
+func EncodeRows(w io.Writer, format Format, rows []Row) error {
+	switch format {
+	case FormatJSON:
+		if rows == nil {
+			rows = []Row{}
+		}
+		data, err := json.MarshalIndent(rows, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(append(data, '\n'))
+		return err
+
+	case FormatNDJSON:
+		enc := json.NewEncoder(w)
+		for _, row := range rows {
+			if err := enc.Encode(row); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(csvHeader); err != nil {
+			return err
+		}
+		for _, row := range rows {
+			record, err := row.csvRecord()
+			if err != nil {
+				return err
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+	return format.Validate()
+}
+
+func (r Row) csvRecord() ([]string, error) {
+	tags := make([]string, len(r.Tags))
+	for i, tag := range r.Tags {
+		tags[i] = string(tag)
+	}
+	createdAt := ""
+	if r.CreatedAt != nil {
+		createdAt = r.CreatedAt.Format(time.RFC3339Nano)
+	}
+	expiresAt := ""
+	if r.ExpiresAt != nil {
+		expiresAt = r.ExpiresAt.Format(time.RFC3339Nano)
+	}
+	deps := make([]string, len(r.DependsOn))
+	for i, id := range r.DependsOn {
+		deps[i] = string(id)
+	}
+	version := ""
+	if r.Version != 0 {
+		version = strconv.FormatInt(int64(r.Version), 10)
+	}
+	spec := ""
+	if len(r.Spec) > 0 {
+		data, err := json.Marshal(r.Spec)
+		if err != nil {
+			return nil, err
+		}
+		spec = string(data)
+	}
+	return []string{
+		string(r.ID),
+		version,
+		string(r.Name),
+		string(r.Kind),
+		string(r.Description),
+		string(r.Status),
+		strings.Join(tags, ","),
+		r.Labels.String(),
+		spec,
+		strings.Join(deps, ","),
+		strconv.FormatBool(r.Enabled),
+		createdAt,
+		expiresAt,
+	}, nil
+}
+
+// DecodedRow is a row read from bulk input, or the error that prevented reading it.
+type DecodedRow struct {
+	Line int // 1-based line (NDJSON, CSV) or element (JSON) number
+	Row  Row
+	Err  error
+}
+
+// DecodeRows reads rows in the given format. Malformed rows are returned with
+// an error instead of stopping the decode; only unreadable input fails as a whole.
+func DecodeRows(r io.Reader, format Format) ([]DecodedRow, error) {
+	switch format {
+	case FormatJSON:
+		var raw []json.RawMessage
+		if err := json.NewDecoder(r).Decode(&raw); err != nil {
+			return nil, fmt.Errorf("decode JSON array: %w", err)
+		}
+		rows := make([]DecodedRow, len(raw))
+		for i, msg := range raw {
+			rows[i] = DecodedRow{Line: i + 1}
+			rows[i].Err = strictUnmarshal(msg, &rows[i].Row)
+		}
+		return rows, nil
+
+	case FormatNDJSON:
+		var rows []DecodedRow
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for line := 1; scanner.Scan(); line++ {
+			text := bytes.TrimSpace(scanner.Bytes())
+			if len(text) == 0 {
+				continue
+			}
+			row := DecodedRow{Line: line}
+			row.Err = strictUnmarshal(text, &row.Row)
+			rows = append(rows, row)
+		}
+		return rows, scanner.Err()
+
+	case FormatCSV:
+		return decodeCSV(r)
+	}
+	return nil, format.Validate()
+}
+
+func strictUnmarshal(data []byte, row *Row) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(row)
+}
+
+func decodeCSV(r io.Reader) ([]DecodedRow, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read CSV header: %w", err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if !slices.Contains(csvHeader, name) {
+			return nil, fmt.Errorf("unknown CSV column %q (valid: %s)", name, strings.Join(csvHeader, ", "))
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, errors.New(`CSV header must include a "name" column`)
+	}
+
+	var rows []DecodedRow
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		var row DecodedRow
+		switch {
+		case err != nil:
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, err
+			}
+			row.Line, row.Err = parseErr.StartLine, parseErr.Err
+		case len(record) != len(header):
+			row.Line, _ = cr.FieldPos(0)
+			row.Err = fmt.Errorf("expected %d fields, found %d", len(header), len(record))
+		default:
+			row.Line, _ = cr.FieldPos(0)
+			row.Row, row.Err = parseCSVRecord(columns, record)
+		}
+		rows = append(rows, row)
+	}
+}
+
+func parseCSVRecord(columns map[string]int, record []string) (Row, error) {
+	cell := func(name string) string {
+		if i, ok := columns[name]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	row := Row{
+		ID:          ID(cell("id")),
+		Name:        Name(cell("name")),
+		Kind:        Kind(cell("kind")),
+		Description: Description(cell("description")),
+		Status:      Status(cell("status")),
+	}
+	if version := cell("version"); version != "" {
+		n, err := strconv.ParseInt(version, 10, 64)
+		if err != nil || n < 0 {
+			return Row{}, fmt.Errorf("version: %q is not a version number", version)
+		}
+		row.Version = Version(n)
+	}
+	for tag := range strings.SplitSeq(cell("tags"), ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			row.Tags = append(row.Tags, Tag(tag))
+		}
+	}
+
+	for id := range strings.SplitSeq(cell("depends_on"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			row.DependsOn = append(row.DependsOn, ID(id))
+		}
+	}
+
+	var labels []Label
+	for label := range strings.SplitSeq(cell("labels"), ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			labels = append(labels, Label(label))
+		}
+	}
+	parsed, err := ParseLabels(labels)
+	if err != nil {
+		return Row{}, err
+	}
+	row.Labels = parsed
+
+	if spec := cell("spec"); spec != "" {
+		if row.Spec, err = ParseSpec([]byte(spec)); err != nil {
+			return Row{}, fmt.Errorf("spec: %w", err)
+		}
+	}
+
+	if enabled := cell("enabled"); enabled != "" {
+		row.Enabled, err = strconv.ParseBool(enabled)
+		if err != nil {
+			return Row{}, fmt.Errorf("enabled: %q is not a boolean", enabled)
+		}
+	}
+	if createdAt := cell("created_at"); createdAt != "" {
+		t, err := time.Parse(time.RFC3339Nano, createdAt)
+		if err != nil {
+			return Row{}, fmt.Errorf("created_at: %q is not an RFC 3339 time", createdAt)
+		}
+		row.CreatedAt = &t
+	}
+	if expiresAt := cell("expires_at"); expiresAt != "" {
+		t, err := time.Parse(time.RFC3339Nano, expiresAt)
+		if err != nil {
+			return Row{}, fmt.Errorf("expires_at: %q is not an RFC 3339 time", expiresAt)
+		}
+		row.ExpiresAt = &t
+	}
+	return row, nil
+}
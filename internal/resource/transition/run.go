@@ -0,0 +1,79 @@
+// Package transition implements resource status transitions
+package transition
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// Result holds the result of a status transition
+type Result struct {
+	Success     bool                  `json:"success"`
+	ResourceID  resource.ID           `json:"resource_id"`
+	Name        resource.Name         `json:"name"`
+	Version     resource.Version      `json:"version"`
+	From        resource.Status       `json:"from"`
+	To          resource.Status       `json:"to"`
+	Next        []resource.Status     `json:"next"`
+	Transitions []resource.Transition `json:"transitions"`
+	Message     resource.Message      `json:"message"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (r Result) MarshalJSON() ([]byte, error) {
+	type Alias Result
+	return json.Marshal((Alias)(r))
+}
+
+// openStore opens the resource store.
+var openStore resource.OpenFunc = resource.Open
+
+// Run moves a resource to a new status along the lifecycle graph
+func Run(ctx context.Context, logger *slog.Logger, cfg Config) (Result, error) {
+	logger.Info("Transitioning resource",
+		"namespace", cfg.Namespace, "ref", cfg.Ref,
+		"to", cfg.To,
+		"actor", cfg.Actor,
+	)
+
+	store, err := openStore(cfg.State)
+	if err != nil {
+		return Result{}, fmt.Errorf("open resource store: %w", err)
+	}
+
+	res, err := resource.Find(ctx, store, cfg.Namespace, cfg.Ref)
+	if err != nil {
+		return Result{}, err
+	}
+	if res.Deleted() {
+		return Result{}, fmt.Errorf("transition %s: %w; restore it first", res.ID, resource.ErrDeleted)
+	}
+
+	from := res.Status
+	if err := res.Transition(cfg.To, cfg.Actor, time.Now().UTC()); err != nil {
+		return Result{}, fmt.Errorf("transition %s: %w", res.ID, err)
+	}
+
+	res, err = store.Update(ctx, res)
+	if err != nil {
+		return Result{}, err
+	}
+
+	logger.Info("Resource transition complete", "resource_id", res.ID, "from", from, "to", res.Status)
+	return Result{
+		Success:     true,
+		ResourceID:  res.ID,
+		Name:        res.Name,
+		Version:     res.Version,
+		From:        from,
+		To:          res.Status,
+		Next:        res.Status.Next(),
+		Transitions: res.Transitions,
+		Message:     resource.Message(fmt.Sprintf("Resource moved from %s to %s", from, res.Status)),
+	}, nil
+}
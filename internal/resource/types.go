@@ -14,14 +14,97 @@ type Description string
 // Tag represents a single resource tag.
 type Tag string
 
+// CreatedAt represents the time a resource was created.
 type CreatedAt time.Time
 
+// MarshalJSON encodes the time as RFC 3339.
+func (c CreatedAt) MarshalJSON() ([]byte, error) { return time.Time(c).MarshalJSON() }
+
+// UnmarshalJSON decodes an RFC 3339 time.
+func (c *CreatedAt) UnmarshalJSON(data []byte) error { return (*time.Time)(c).UnmarshalJSON(data) }
+
+// MarshalText encodes the time as RFC 3339.
+func (c CreatedAt) MarshalText() ([]byte, error) { return time.Time(c).MarshalText() }
+
+// UnmarshalText decodes an RFC 3339 time.
+func (c *CreatedAt) UnmarshalText(data []byte) error { return (*time.Time)(c).UnmarshalText(data) }
+
+// String returns the time in RFC 3339 format.
+func (c CreatedAt) String() string { return time.Time(c).Format(time.RFC3339Nano) }
+
+// UpdatedAt represents the time a resource was last changed.
+type UpdatedAt time.Time
+
+// MarshalJSON encodes the time as RFC 3339.
+func (u UpdatedAt) MarshalJSON() ([]byte, error) { return time.Time(u).MarshalJSON() }
+
+// UnmarshalJSON decodes an RFC 3339 time.
+func (u *UpdatedAt) UnmarshalJSON(data []byte) error { return (*time.Time)(u).UnmarshalJSON(data) }
+
+// MarshalText encodes the time as RFC 3339.
+func (u UpdatedAt) MarshalText() ([]byte, error) { return time.Time(u).MarshalText() }
+
+// UnmarshalText decodes an RFC 3339 time.
+func (u *UpdatedAt) UnmarshalText(data []byte) error { return (*time.Time)(u).UnmarshalText(data) }
+
+// String returns the time in RFC 3339 format.
+func (u UpdatedAt) String() string { return time.Time(u).Format(time.RFC3339Nano) }
+
+// DeletedAt represents the time a resource was moved to the trash.
+type DeletedAt time.Time
+
+// MarshalJSON encodes the time as RFC 3339.
+func (d DeletedAt) MarshalJSON() ([]byte, error) { return time.Time(d).MarshalJSON() }
+
+// UnmarshalJSON decodes an RFC 3339 time.
+func (d *DeletedAt) UnmarshalJSON(data []byte) error { return (*time.Time)(d).UnmarshalJSON(data) }
+
+// MarshalText encodes the time as RFC 3339.
+func (d DeletedAt) MarshalText() ([]byte, error) { return time.Time(d).MarshalText() }
+
+// UnmarshalText decodes an RFC 3339 time.
+func (d *DeletedAt) UnmarshalText(data []byte) error { return (*time.Time)(d).UnmarshalText(data) }
+
+// String returns the time in RFC 3339 format.
+func (d DeletedAt) String() string { return time.Time(d).Format(time.RFC3339Nano) }
+
+// ExpiresAt represents the time a resource expires and becomes due for reaping.
+type ExpiresAt time.Time
+
+// MarshalJSON encodes the time as RFC 3339.
+func (e ExpiresAt) MarshalJSON() ([]byte, error) { return time.Time(e).MarshalJSON() }
+
+// UnmarshalJSON decodes an RFC 3339 time.
+func (e *ExpiresAt) UnmarshalJSON(data []byte) error { return (*time.Time)(e).UnmarshalJSON(data) }
+
+// MarshalText encodes the time as RFC 3339.
+func (e ExpiresAt) MarshalText() ([]byte, error) { return time.Time(e).MarshalText() }
+
+// UnmarshalText decodes an RFC 3339 time.
+func (e *ExpiresAt) UnmarshalText(data []byte) error { return (*time.Time)(e).UnmarshalText(data) }
+
+// String returns the time in RFC 3339 format.
+func (e ExpiresAt) String() string { return time.Time(e).Format(time.RFC3339Nano) }
+
+// TTL represents how long a resource lives before it expires.
+type TTL time.Duration
+
+// Age represents how long ago something happened.
+type Age time.Duration
+
 // Message represents a resource message.
 type Message string
 
 // ID represents a unique resource identifier.
 type ID string
 
+// Ref identifies a resource by ID or name.
+type Ref string
+
+// Version counts the changes made to a resource. It starts at 1 when the
+// resource is created and increases by one with every update.
+type Version int64
+
 // Status represents a resource status.
 type Status string
 
@@ -43,3 +126,37 @@ type Offset int
 
 // SortField represents a field name to sort by.
 type SortField string
+
+// StateDir represents the directory holding persisted resource state.
+type StateDir string
+
+// Resource is a single stored resource record.
+type Resource struct {
+	ID          ID           `json:"id"`
+	Namespace   Namespace    `json:"namespace"`
+	Version     Version      `json:"version"`
+	Name        Name         `json:"name"`
+	Kind        Kind         `json:"kind,omitempty"`
+	Description Description  `json:"description"`
+	Status      Status       `json:"status"`
+	Tags        []Tag        `json:"tags"`
+	Labels      Labels       `json:"labels,omitempty"`
+	Spec        Spec         `json:"spec,omitempty"` // Custom attributes, checked against the schema of Kind
+	DependsOn   []ID         `json:"depends_on,omitempty"`
+	Enabled     bool         `json:"enabled"`
+	CreatedAt   CreatedAt    `json:"created_at"`
+	UpdatedAt   UpdatedAt    `json:"updated_at"`
+	ExpiresAt   *ExpiresAt   `json:"expires_at,omitempty"`  // When the resource is due for reaping; nil never expires
+	DeletedAt   *DeletedAt   `json:"deleted_at,omitempty"`  // Set while the resource is in the trash
+	Transitions []Transition `json:"transitions,omitempty"` // Status history, oldest first
+}
+
+// Deleted reports whether the resource is in the trash.
+func (r Resource) Deleted() bool {
+	return r.DeletedAt != nil
+}
+
+// Expired reports whether the resource has an expiry at or before now.
+func (r Resource) Expired(now time.Time) bool {
+	return r.ExpiresAt != nil && !time.Time(*r.ExpiresAt).After(now)
+}
@@ -0,0 +1,87 @@
+package update
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/app/log"
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// Config holds configuration for updating a resource
+type Config struct {
+	Ref            resource.Ref              // Resource ID or name
+	Description    resource.Description      // New description
+	SetDescription bool                      // Whether the description was given
+	Enabled        bool                      // New enabled state
+	SetEnabled     bool                      // Whether the enabled state was given
+	Status         resource.Status           // New status (empty = unchanged)
+	AddTags        []resource.Tag            // Tags to add
+	RemoveTags     []resource.Tag            // Tags to remove
+	SetLabels      []resource.Label          // Labels to add or overwrite (key=value)
+	RemoveLabels   []resource.LabelKey       // Label keys to remove
+	SpecFile       app.FilePath              // JSON file replacing the whole spec
+	Spec           []resource.SpecAssignment // Spec values to set (path=value)
+	UnsetSpec      []resource.SpecPath       // Spec paths to remove
+	AddDeps        []resource.Ref            // Resources (ID or name) to depend on
+	RemoveDeps     []resource.Ref            // Resources (ID or name) to stop depending on
+	Actor          resource.Actor            // Who is making the change
+	IfVersion      resource.Version          // Expected current version (0 = any)
+	Namespace      resource.Namespace        // Namespace of the resource
+	State          resource.StateDir         // Resource store directory
+	Output         app.FilePath              // Output file path
+	Logging        log.Config
+}
+
+func (c Config) OutputFilePath() app.FilePath { return c.Output }
+func (c Config) LoggerConfig() log.Config     { return c.Logging }
+
+// Validate implements app.Validator
+func (c Config) Validate() error {
+	var v app.ValidationError
+	v.Check("namespace", c.Namespace.Validate())
+	v.Check("id|name", c.Ref.Validate())
+	if c.IfVersion < 0 {
+		v.Check("if-version", errors.New("must not be negative"))
+	}
+	if c.Status != "" {
+		v.Check("status", c.Status.Validate())
+	}
+	if c.SetDescription {
+		v.Check("description", c.Description.Validate())
+	}
+	for i, tag := range c.AddTags {
+		v.Check(fmt.Sprintf("add-tag[%d]", i), tag.Validate())
+		if slices.Contains(c.RemoveTags, tag) {
+			v.Check(fmt.Sprintf("add-tag[%d]", i), fmt.Errorf("%q is also removed", tag))
+		}
+	}
+	for i, tag := range c.RemoveTags {
+		v.Check(fmt.Sprintf("remove-tag[%d]", i), tag.Validate())
+	}
+	for i, label := range c.SetLabels {
+		v.Check(fmt.Sprintf("label[%d]", i), label.Validate())
+		if key, _, err := label.Split(); err == nil && slices.Contains(c.RemoveLabels, resource.LabelKey(key)) {
+			v.Check(fmt.Sprintf("label[%d]", i), fmt.Errorf("%q is also removed", key))
+		}
+	}
+	for i, key := range c.RemoveLabels {
+		v.Check(fmt.Sprintf("remove-label[%d]", i), key.Validate())
+	}
+	for i, a := range c.Spec {
+		v.Check(fmt.Sprintf("set[%d]", i), a.Validate())
+	}
+	for i, ref := range c.AddDeps {
+		v.Check(fmt.Sprintf("add-dependency[%d]", i), ref.Validate())
+	}
+	for i, ref := range c.RemoveDeps {
+		v.Check(fmt.Sprintf("remove-dependency[%d]", i), ref.Validate())
+	}
+	for i, path := range c.UnsetSpec {
+		_, err := path.Segments()
+		v.Check(fmt.Sprintf("unset[%d]", i), err)
+	}
+	return v.Err()
+}
@@ -0,0 +1,197 @@
+// Package update implements resource update logic
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// Result holds the result of a resource update
+type Result struct {
+	Success    bool             `json:"success"`
+	ResourceID resource.ID      `json:"resource_id"`
+	Name       resource.Name    `json:"name"`
+	Version    resource.Version `json:"version"`
+	Changes    resource.Changes `json:"changes"`
+	Message    resource.Message `json:"message"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (r Result) MarshalJSON() ([]byte, error) {
+	type Alias Result
+	return json.Marshal((Alias)(r))
+}
+
+// openStore opens the resource store.
+var openStore resource.OpenFunc = resource.Open
+
+// openSchemas opens the spec schema registry.
+var openSchemas = resource.OpenSchemas
+
+// Run applies the configured changes to a resource
+func Run(ctx context.Context, logger *slog.Logger, cfg Config) (Result, error) {
+	logger.Info("Updating resource",
+		"namespace", cfg.Namespace, "ref", cfg.Ref,
+		"description", cfg.Description,
+		"enabled", cfg.Enabled,
+		"status", cfg.Status,
+		"actor", cfg.Actor,
+		"add_tags", cfg.AddTags,
+		"remove_tags", cfg.RemoveTags,
+		"labels", cfg.SetLabels,
+		"remove_labels", cfg.RemoveLabels,
+		"spec_file", cfg.SpecFile,
+		"spec", cfg.Spec,
+		"unset_spec", cfg.UnsetSpec,
+		"add_dependencies", cfg.AddDeps,
+		"remove_dependencies", cfg.RemoveDeps,
+		"if_version", cfg.IfVersion,
+	)
+
+	store, err := openStore(cfg.State)
+	if err != nil {
+		return Result{}, fmt.Errorf("open resource store: %w", err)
+	}
+
+	before, err := resource.Find(ctx, store, cfg.Namespace, cfg.Ref)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if before.Deleted() {
+		return Result{}, fmt.Errorf("update %s: %w; restore it first", before.ID, resource.ErrDeleted)
+	}
+	if err := resource.CheckVersion(before, cfg.IfVersion); err != nil {
+		return Result{}, err
+	}
+
+	after, err := apply(before, cfg, time.Now().UTC())
+	if err != nil {
+		return Result{}, fmt.Errorf("update %s: %w", before.ID, err)
+	}
+	if after.DependsOn, err = dependencies(ctx, store, before, cfg); err != nil {
+		return Result{}, fmt.Errorf("update %s: %w", before.ID, err)
+	}
+	if !after.Spec.Equal(before.Spec) {
+		schemas, err := openSchemas(cfg.State)
+		if err != nil {
+			return Result{}, err
+		}
+		if err := schemas.Validate(after.Kind, after.Spec); err != nil {
+			return Result{}, err
+		}
+	}
+	result := Result{
+		Success:    true,
+		ResourceID: before.ID,
+		Name:       before.Name,
+		Version:    before.Version,
+		Changes:    resource.Diff(before, after),
+		Message:    "Resource updated successfully",
+	}
+
+	if len(result.Changes) == 0 {
+		result.Message = "No changes"
+		logger.Info("Resource update complete", "resource_id", result.ResourceID, "changes", 0)
+		return result, nil
+	}
+
+	// after carries the version read above, so a concurrent change makes this fail.
+	updated, err := store.Update(ctx, after)
+	if err != nil {
+		return Result{}, err
+	}
+	result.Version = updated.Version
+
+	logger.Info("Resource update complete", "resource_id", result.ResourceID, "changes", len(result.Changes))
+	return result, nil
+}
+
+// apply returns res with the changes in cfg applied. Status changes must follow the lifecycle.
+func apply(res resource.Resource, cfg Config, now time.Time) (resource.Resource, error) {
+	if cfg.SetDescription {
+		res.Description = cfg.Description
+	}
+	if cfg.SetEnabled {
+		res.Enabled = cfg.Enabled
+	}
+	if cfg.Status != "" && cfg.Status != res.Status {
+		if err := res.Transition(cfg.Status, cfg.Actor, now); err != nil {
+			return resource.Resource{}, err
+		}
+	}
+
+	tags := slices.Clone(res.Tags)
+	for _, tag := range cfg.AddTags {
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	tags = slices.DeleteFunc(tags, func(tag resource.Tag) bool {
+		return slices.Contains(cfg.RemoveTags, tag)
+	})
+	res.Tags = tags
+
+	set, err := resource.ParseLabels(cfg.SetLabels)
+	if err != nil {
+		return resource.Resource{}, err
+	}
+	labels := maps.Clone(res.Labels)
+	if labels == nil && len(set) > 0 {
+		labels = resource.Labels{}
+	}
+	maps.Copy(labels, set)
+	for _, key := range cfg.RemoveLabels {
+		delete(labels, string(key))
+	}
+	if len(labels) == 0 {
+		labels = nil
+	}
+	res.Labels = labels
+
+	spec := res.Spec
+	if cfg.SpecFile != "" {
+		if spec, err = resource.ReadSpecFile(string(cfg.SpecFile)); err != nil {
+			return resource.Resource{}, err
+		}
+	}
+	if res.Spec, err = spec.Apply(cfg.Spec, cfg.UnsetSpec); err != nil {
+		return resource.Resource{}, err
+	}
+
+	return res, nil
+}
+
+// dependencies returns the dependencies of res with the changes in cfg applied.
+// Cycles are rejected by the store when the update is written.
+func dependencies(ctx context.Context, store resource.Store, res resource.Resource, cfg Config) ([]resource.ID, error) {
+	add, err := resource.ResolveDependencies(ctx, store, cfg.Namespace, cfg.AddDeps)
+	if err != nil {
+		return nil, err
+	}
+	remove, err := resource.ResolveDependencies(ctx, store, cfg.Namespace, cfg.RemoveDeps)
+	if err != nil {
+		return nil, err
+	}
+
+	deps := slices.Clone(res.DependsOn)
+	for _, id := range add {
+		if !slices.Contains(deps, id) {
+			deps = append(deps, id)
+		}
+	}
+	deps = slices.DeleteFunc(deps, func(id resource.ID) bool {
+		return slices.Contains(remove, id)
+	})
+	if len(deps) == 0 {
+		return nil, nil
+	}
+	return deps, nil
+}
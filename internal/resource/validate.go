@@ -0,0 +1,68 @@
+package resource
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+)
+
+// Validation limits.
+const (
+	MaxNameLength        = 63
+	MaxTagLength         = 63
+	MaxDescriptionLength = 1024
+)
+
+var (
+	// namePattern matches DNS labels (RFC 1123).
+	namePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+	// tagPattern matches letters, digits and - _ . : /
+	tagPattern = regexp.MustCompile(`^[A-Za-z0-9_.:/-]+$`)
+)
+
+// Validate checks that the name is a DNS label: lowercase letters, digits and
+// hyphens, starting and ending with a letter or digit, at most 63 characters.
+func (n Name) Validate() error {
+	switch {
+	case n == "":
+		return errors.New("must not be empty")
+	case len(n) > MaxNameLength:
+		return fmt.Errorf("must be at most %d characters", MaxNameLength)
+	case !namePattern.MatchString(string(n)):
+		return fmt.Errorf("%q must consist of lowercase letters, digits and '-', and start and end with a letter or digit", n)
+	}
+	return nil
+}
+
+// Validate checks that the tag is 1-63 characters of letters, digits and - _ . : /
+func (t Tag) Validate() error {
+	switch {
+	case t == "":
+		return errors.New("must not be empty")
+	case len(t) > MaxTagLength:
+		return fmt.Errorf("must be at most %d characters", MaxTagLength)
+	case !tagPattern.MatchString(string(t)):
+		return fmt.Errorf("%q may only contain letters, digits and - _ . : /", t)
+	}
+	return nil
+}
+
+// Validate checks that the description is valid UTF-8 of at most 1024 characters.
+func (d Description) Validate() error {
+	switch {
+	case !utf8.ValidString(string(d)):
+		return errors.New("must be valid UTF-8")
+	case utf8.RuneCountInString(string(d)) > MaxDescriptionLength:
+		return fmt.Errorf("must be at most %d characters", MaxDescriptionLength)
+	}
+	return nil
+}
+
+// Validate checks that the reference is not empty.
+func (r Ref) Validate() error {
+	if r == "" {
+		return errors.New("resource ID or name is required")
+	}
+	return nil
+}
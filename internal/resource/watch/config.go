@@ -0,0 +1,33 @@
+package watch
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/app/log"
+	"github.com/gomatic/modern-go-application/internal/resource"
+	"github.com/gomatic/modern-go-application/internal/resource/list"
+)
+
+// Config holds configuration for watching resources
+type Config struct {
+	list.Filter                   // Which resources to report changes to, as for list
+	Interval    time.Duration     // How often the store is polled for changes
+	State       resource.StateDir // Resource store directory
+	Output      app.FilePath      // Output file path
+	Logging     log.Config
+}
+
+// Validate implements app.Validator
+func (c Config) Validate() error {
+	var v app.ValidationError
+	c.Filter.Check(&v)
+	if c.Interval <= 0 {
+		v.Check("interval", errors.New("must be positive"))
+	}
+	return v.Err()
+}
+
+func (c Config) OutputFilePath() app.FilePath { return c.Output }
+func (c Config) LoggerConfig() log.Config     { return c.Logging }
@@ -0,0 +1,233 @@
+// Package watch implements streaming of resource changes
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/gomatic/modern-go-application/internal/app"
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+// EventType classifies a change.
+type EventType string
+
+// Event types.
+const (
+	EventCreated EventType = "created"
+	EventUpdated EventType = "updated"
+	EventDeleted EventType = "deleted"
+)
+
+// Revision identifies the revision behind an event. The resource it produced
+// is the event's Resource.
+type Revision struct {
+	Number    resource.RevisionNumber `json:"revision"`
+	Operation resource.Operation      `json:"operation"`
+	At        time.Time               `json:"at"`
+	Changes   resource.Changes        `json:"changes,omitempty"`
+}
+
+// Event is a single change, written as one line of NDJSON. Revision is absent
+// when a resource was deleted permanently, since its history goes with it.
+type Event struct {
+	Type     EventType         `json:"type"`
+	Resource resource.Resource `json:"resource"`
+	Revision *Revision         `json:"revision,omitempty"`
+}
+
+// Result summarises a watch once it stops. Events are streamed while it runs,
+// so the result itself is not written.
+type Result struct {
+	Success bool             `json:"success"`
+	Events  int              `json:"events"`
+	Message resource.Message `json:"message"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (r Result) MarshalJSON() ([]byte, error) {
+	type Alias Result
+	return json.Marshal((Alias)(r))
+}
+
+// Streamed implements app.Streamer
+func (Result) Streamed() {}
+
+// openStore opens the resource store.
+var openStore resource.OpenFunc = resource.Open
+
+// Run polls the store every cfg.Interval and writes an event for each change
+// until ctx is cancelled. Cancellation is a clean stop, not an error.
+func Run(ctx context.Context, logger *slog.Logger, cfg Config) (Result, error) {
+	logger.Info("Watching resources",
+		"namespace", cfg.Namespace,
+		"all_namespaces", cfg.AllNamespaces,
+		"include", cfg.IncludePatterns,
+		"exclude", cfg.ExcludePatterns,
+		"statuses", cfg.Statuses,
+		"selector", cfg.Selector,
+		"where", cfg.Where,
+		"created_after", cfg.CreatedAfter,
+		"created_before", cfg.CreatedBefore,
+		"updated_since", cfg.UpdatedSince,
+		"interval", cfg.Interval,
+	)
+
+	// Moving a resource to the trash is a change like any other, so the
+	// resources matched include those in the trash.
+	cfg.IncludeDeleted = true
+	match, err := cfg.Filter.Matcher(time.Now().UTC())
+	if err != nil {
+		return Result{}, err
+	}
+
+	store, err := openStore(cfg.State)
+	if err != nil {
+		return Result{}, fmt.Errorf("open resource store: %w", err)
+	}
+
+	out, err := app.OpenOutput(logger, cfg.Output)
+	if err != nil {
+		return Result{}, err
+	}
+	defer out.Close()
+	enc := json.NewEncoder(out)
+
+	count := 0
+	stop := func(err error) (Result, error) {
+		result := Result{Success: true, Events: count, Message: "Watch stopped"}
+		return app.Stopped(ctx, logger, result, err, "Resource watch stopped", "events", count)
+	}
+
+	w := &watcher{store: store, since: time.Now().UTC(), seen: map[resource.ID]resource.RevisionNumber{}}
+	w.known, err = w.snapshot(ctx)
+	if err != nil {
+		return stop(err)
+	}
+
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return stop(ctx.Err())
+		case <-ticker.C:
+		}
+
+		events, err := w.poll(ctx)
+		if err != nil {
+			return stop(err)
+		}
+		for _, event := range events {
+			if !match.Match(event.Resource) {
+				continue
+			}
+			if err := enc.Encode(event); err != nil {
+				return Result{}, fmt.Errorf("write event: %w", err)
+			}
+			count++
+		}
+	}
+}
+
+// watcher detects changes between successive snapshots of the store.
+type watcher struct {
+	store resource.Store
+	since time.Time                               // Revisions before this were made before the watch started
+	known map[resource.ID]resource.Resource       // The previous snapshot
+	seen  map[resource.ID]resource.RevisionNumber // Latest revision already reported per resource
+}
+
+func (w *watcher) snapshot(ctx context.Context) (map[resource.ID]resource.Resource, error) {
+	all, err := w.store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[resource.ID]resource.Resource, len(all))
+	for _, res := range all {
+		known[res.ID] = res
+	}
+	return known, nil
+}
+
+// poll takes a new snapshot and returns the events for everything that
+// changed since the previous one, oldest first. Permanent deletions have no
+// revision time and come last.
+func (w *watcher) poll(ctx context.Context) ([]Event, error) {
+	all, err := w.store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	current := make(map[resource.ID]resource.Resource, len(all))
+	for _, res := range all {
+		current[res.ID] = res
+		// Compare versions, not contents: a change reverted within one
+		// interval leaves the resource as it was but still has revisions.
+		if prev, ok := w.known[res.ID]; ok && prev.Version == res.Version {
+			continue
+		}
+		revisions, err := w.revisions(ctx, res.ID)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, revisions...)
+	}
+	slices.SortStableFunc(events, func(a, b Event) int {
+		return a.Revision.At.Compare(b.Revision.At)
+	})
+
+	for id, res := range w.known {
+		if _, ok := current[id]; !ok {
+			events = append(events, Event{Type: EventDeleted, Resource: res})
+			delete(w.seen, id)
+		}
+	}
+
+	w.known = current
+	return events, nil
+}
+
+// revisions returns an event for each revision of id not yet reported.
+func (w *watcher) revisions(ctx context.Context, id resource.ID) ([]Event, error) {
+	history, err := w.store.History(ctx, id)
+	if errors.Is(err, resource.ErrNotFound) {
+		return nil, nil // Deleted since the snapshot; reported on the next poll
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	for _, rev := range history {
+		if rev.Number <= w.seen[id] || rev.At.Before(w.since) {
+			continue
+		}
+		events = append(events, Event{
+			Type:     eventType(rev.Operation),
+			Resource: rev.Snapshot,
+			Revision: &Revision{Number: rev.Number, Operation: rev.Operation, At: rev.At, Changes: rev.Changes},
+		})
+	}
+	if len(history) > 0 {
+		w.seen[id] = history[len(history)-1].Number
+	}
+	return events, nil
+}
+
+func eventType(op resource.Operation) EventType {
+	switch op {
+	case resource.OperationCreate:
+		return EventCreated
+	case resource.OperationDelete:
+		return EventDeleted
+	default:
+		return EventUpdated
+	}
+}
@@ -0,0 +1,54 @@
+package watch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gomatic/modern-go-application/internal/resource"
+)
+
+func TestPollRevertedChange(t *testing.T) {
+	ctx := context.Background()
+	store := resource.NewMemoryStore()
+	w := &watcher{store: store, since: time.Now().UTC(), seen: map[resource.ID]resource.RevisionNumber{}}
+	var err error
+	if w.known, err = w.snapshot(ctx); err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+
+	res, err := store.Create(ctx, resource.Resource{ID: "res-1", Name: "db"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if events, err := w.poll(ctx); err != nil || len(events) != 1 || events[0].Type != EventCreated {
+		t.Fatalf("poll after create = %v, %v; want one created event", events, err)
+	}
+
+	// Changed and changed back between two polls: the resource looks the
+	// same, but both revisions are still reported.
+	res.Description = "temporary"
+	if res, err = store.Update(ctx, res); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	res.Description = ""
+	if _, err = store.Update(ctx, res); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	events, err := w.poll(ctx)
+	if err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2", len(events))
+	}
+	for i, event := range events {
+		if event.Type != EventUpdated || event.Revision == nil || event.Revision.Number != resource.RevisionNumber(i+2) {
+			t.Errorf("event %d = %+v, want update revision %d", i, event, i+2)
+		}
+	}
+
+	if events, err := w.poll(ctx); err != nil || len(events) != 0 {
+		t.Errorf("idle poll = %v, %v; want no events", events, err)
+	}
+}
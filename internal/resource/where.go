@@ -0,0 +1,639 @@
+package resource
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Where is a filter expression over resource fields, e.g.
+// `status == "active" && "prod" in tags && created_at > "2025-01-01"`.
+type Where string
+
+// WhereFilter is a parsed Where expression. The nil WhereFilter matches
+// everything.
+type WhereFilter struct {
+	root whereExpr
+}
+
+// Matches reports whether res satisfies the filter.
+func (f *WhereFilter) Matches(res Resource) bool {
+	if f == nil {
+		return true
+	}
+	matched, _ := f.root.eval(res).(bool)
+	return matched
+}
+
+// WhereFields returns the names of the fields a filter can refer to.
+func WhereFields() []string {
+	return slices.Sorted(maps.Keys(whereFields))
+}
+
+// Parse parses the expression. Supported syntax:
+//
+//	a == b  a != b  a < b  a <= b  a > b  a >= b
+//	"x" in tags  "key" in labels  status in ["active", "pending"]
+//	a && b  a || b  !a  (a)
+//	labels.key  labels["key"]
+//
+// Operands are fields (see WhereFields), strings in single or double quotes,
+// numbers, true, false and null. Strings compared with times are read as a
+// TimeSpec resolved against now, so created_at > "7d" matches resources
+// created in the week before now.
+// A label the resource does not have reads as the empty string,
+// expires_at is null for resources that never expire, and deleted_at is null
+// unless the resource is in the trash.
+func (w Where) Parse(now time.Time) (*WhereFilter, error) {
+	if strings.TrimSpace(string(w)) == "" {
+		return nil, nil
+	}
+	p := &whereParser{lexer: whereLexer{input: string(w)}, now: now}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	root, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != wtEOF {
+		return nil, p.errorf(p.tok.pos, "expected an operator or end of filter, found %s", p.tok)
+	}
+	if root.typ() != typeBool {
+		return nil, p.errorf(0, "filter must be a condition, not a %s", root.typ())
+	}
+	return &WhereFilter{root: root}, nil
+}
+
+// whereType is the static type of a filter expression.
+type whereType int
+
+const (
+	typeString whereType = iota
+	typeNumber
+	typeBool
+	typeTime
+	typeList
+	typeMap
+	typeNull
+)
+
+func (t whereType) String() string {
+	return [...]string{"string", "number", "boolean", "time", "list", "map", "null"}[t]
+}
+
+// whereFields maps field names to their type and accessor. Times are
+// *time.Time so that an unset expires_at or deleted_at is null.
+var whereFields = map[string]struct {
+	typ whereType
+	get func(Resource) any
+}{
+	"id":        {typeString, func(r Resource) any { return string(r.ID) }},
+	"namespace": {typeString, func(r Resource) any { return string(r.Namespace) }},
+	"version":   {typeNumber, func(r Resource) any { return float64(r.Version) }},
+	"name":      {typeString, func(r Resource) any { return string(r.Name) }},
+	"kind":      {typeString, func(r Resource) any { return string(r.Kind) }},
+	"status":    {typeString, func(r Resource) any { return string(r.Status) }},
+	"tags": {typeList, func(r Resource) any {
+		tags := make([]string, len(r.Tags))
+		for i, tag := range r.Tags {
+			tags[i] = string(tag)
+		}
+		return tags
+	}},
+	"labels": {typeMap, func(r Resource) any { return map[string]string(r.Labels) }},
+	"created_at": {typeTime, func(r Resource) any {
+		t := time.Time(r.CreatedAt)
+		return &t
+	}},
+	"updated_at": {typeTime, func(r Resource) any {
+		t := time.Time(r.UpdatedAt)
+		return &t
+	}},
+	"expires_at": {typeTime, func(r Resource) any {
+		if r.ExpiresAt == nil {
+			return (*time.Time)(nil)
+		}
+		t := time.Time(*r.ExpiresAt)
+		return &t
+	}},
+	"deleted_at": {typeTime, func(r Resource) any {
+		if r.DeletedAt == nil {
+			return (*time.Time)(nil)
+		}
+		t := time.Time(*r.DeletedAt)
+		return &t
+	}},
+}
+
+// whereExpr is a node of a parsed filter.
+type whereExpr interface {
+	typ() whereType
+	eval(Resource) any
+}
+
+type literalExpr struct {
+	t     whereType
+	value any
+}
+
+func (e literalExpr) typ() whereType    { return e.t }
+func (e literalExpr) eval(Resource) any { return e.value }
+func (e literalExpr) String() string {
+	if s, ok := e.value.(string); ok {
+		return strconv.Quote(s)
+	}
+	return fmt.Sprintf("%v", e.value)
+}
+
+type fieldExpr struct{ name string }
+
+func (e fieldExpr) typ() whereType      { return whereFields[e.name].typ }
+func (e fieldExpr) eval(r Resource) any { return whereFields[e.name].get(r) }
+func (e fieldExpr) String() string      { return e.name }
+
+type labelExpr struct{ key string }
+
+func (e labelExpr) typ() whereType      { return typeString }
+func (e labelExpr) eval(r Resource) any { return r.Labels[e.key] }
+func (e labelExpr) String() string      { return "labels." + e.key }
+
+type listExpr struct{ items []string }
+
+func (e listExpr) typ() whereType    { return typeList }
+func (e listExpr) eval(Resource) any { return e.items }
+
+type notExpr struct{ x whereExpr }
+
+func (e notExpr) typ() whereType      { return typeBool }
+func (e notExpr) eval(r Resource) any { return !e.x.eval(r).(bool) }
+
+type logicalExpr struct {
+	and  bool
+	l, r whereExpr
+}
+
+func (e logicalExpr) typ() whereType { return typeBool }
+
+func (e logicalExpr) eval(r Resource) any {
+	if e.and {
+		return e.l.eval(r).(bool) && e.r.eval(r).(bool)
+	}
+	return e.l.eval(r).(bool) || e.r.eval(r).(bool)
+}
+
+type compareExpr struct {
+	op   string
+	l, r whereExpr
+}
+
+func (e compareExpr) typ() whereType { return typeBool }
+
+func (e compareExpr) eval(r Resource) any {
+	cmp, ok := compareValues(e.l.eval(r), e.r.eval(r))
+	switch e.op {
+	case "==":
+		return ok && cmp == 0
+	case "!=":
+		return !ok || cmp != 0
+	case "<":
+		return ok && cmp < 0
+	case "<=":
+		return ok && cmp <= 0
+	case ">":
+		return ok && cmp > 0
+	default: // ">="
+		return ok && cmp >= 0
+	}
+}
+
+// compareValues orders two values of the same type. A null time is only
+// equal to another null time and is not ordered against anything.
+func compareValues(a, b any) (int, bool) {
+	switch a := a.(type) {
+	case string:
+		return strings.Compare(a, b.(string)), true
+	case float64:
+		b := b.(float64)
+		switch {
+		case a < b:
+			return -1, true
+		case a > b:
+			return 1, true
+		}
+		return 0, true
+	case bool:
+		if a == b.(bool) {
+			return 0, true
+		}
+		return 1, true
+	case *time.Time:
+		b, _ := b.(*time.Time)
+		if a == nil || b == nil {
+			if a == nil && b == nil {
+				return 0, true
+			}
+			return 0, false
+		}
+		return a.Compare(*b), true
+	}
+	return 0, false
+}
+
+type membershipExpr struct {
+	needle   whereExpr
+	haystack whereExpr
+}
+
+func (e membershipExpr) typ() whereType { return typeBool }
+
+func (e membershipExpr) eval(r Resource) any {
+	needle := e.needle.eval(r).(string)
+	switch haystack := e.haystack.eval(r).(type) {
+	case []string:
+		return slices.Contains(haystack, needle)
+	case map[string]string:
+		_, ok := haystack[needle]
+		return ok
+	}
+	return false
+}
+
+type whereParser struct {
+	lexer whereLexer
+	tok   whereToken
+	now   time.Time // Relative times resolve against this
+}
+
+func (p *whereParser) next() error {
+	tok, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *whereParser) errorf(pos int, format string, args ...any) error {
+	return whereErrorf(pos, format, args...)
+}
+
+func whereErrorf(pos int, format string, args ...any) error {
+	return fmt.Errorf("invalid filter at column %d: %s", pos+1, fmt.Sprintf(format, args...))
+}
+
+func (p *whereParser) or() (whereExpr, error) {
+	return p.logical(wtOr, p.and)
+}
+
+func (p *whereParser) and() (whereExpr, error) {
+	return p.logical(wtAnd, p.unary)
+}
+
+// logical parses operands joined by op, all of which must be conditions.
+func (p *whereParser) logical(op whereTokenKind, operand func() (whereExpr, error)) (whereExpr, error) {
+	start := p.tok.pos
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok.kind == op {
+		opTok := p.tok
+		if left.typ() != typeBool {
+			return nil, p.errorf(start, "left of %s must be a condition, not a %s", opTok, left.typ())
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		start = p.tok.pos
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		if right.typ() != typeBool {
+			return nil, p.errorf(start, "right of %s must be a condition, not a %s", opTok, right.typ())
+		}
+		left = logicalExpr{and: op == wtAnd, l: left, r: right}
+	}
+	return left, nil
+}
+
+func (p *whereParser) unary() (whereExpr, error) {
+	if p.tok.kind != wtNot {
+		return p.comparison()
+	}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	start := p.tok.pos
+	x, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	if x.typ() != typeBool {
+		return nil, p.errorf(start, "'!' needs a condition, not a %s", x.typ())
+	}
+	return notExpr{x: x}, nil
+}
+
+func (p *whereParser) comparison() (whereExpr, error) {
+	lpos := p.tok.pos
+	left, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+
+	opTok := p.tok
+	switch opTok.kind {
+	case wtCompare, wtIn:
+	default:
+		return left, nil
+	}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	rpos := p.tok.pos
+	right, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+
+	if opTok.kind == wtIn {
+		if left.typ() != typeString {
+			return nil, p.errorf(lpos, "left of 'in' must be a string, not a %s", left.typ())
+		}
+		if right.typ() != typeList && right.typ() != typeMap {
+			return nil, p.errorf(rpos, "right of 'in' must be tags, labels or a list, not a %s", right.typ())
+		}
+		return membershipExpr{needle: left, haystack: right}, nil
+	}
+
+	if left, err = p.coerceTime(left, right.typ(), lpos); err != nil {
+		return nil, err
+	}
+	if right, err = p.coerceTime(right, left.typ(), rpos); err != nil {
+		return nil, err
+	}
+	lt, rt := left.typ(), right.typ()
+	nullable := (lt == typeTime && rt == typeNull) || (lt == typeNull && rt == typeTime)
+	if lt != rt && !nullable {
+		return nil, p.errorf(opTok.pos, "cannot compare %s %s with %s %s", lt, left, rt, right)
+	}
+	switch {
+	case opTok.text != "==" && opTok.text != "!=" && (nullable || lt == typeBool):
+		return nil, p.errorf(opTok.pos, "%s cannot order %s values", opTok, lt)
+	case lt == typeList || lt == typeMap:
+		return nil, p.errorf(opTok.pos, "%s cannot compare %s values; use 'in'", opTok, lt)
+	}
+	return compareExpr{op: opTok.text, l: left, r: right}, nil
+}
+
+// coerceTime turns a string literal compared with a time into a time literal.
+func (p *whereParser) coerceTime(e whereExpr, other whereType, pos int) (whereExpr, error) {
+	lit, ok := e.(literalExpr)
+	if !ok || other != typeTime || lit.t != typeString {
+		return e, nil
+	}
+	t, err := TimeSpec(lit.value.(string)).Resolve(p.now)
+	if err != nil {
+		return nil, whereErrorf(pos, "%v", err)
+	}
+	return literalExpr{t: typeTime, value: &t}, nil
+}
+
+func (p *whereParser) operand() (whereExpr, error) {
+	tok := p.tok
+	switch tok.kind {
+	case wtString:
+		return literalExpr{t: typeString, value: tok.value}, p.next()
+
+	case wtNumber:
+		n, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, p.errorf(tok.pos, "%q is not a number", tok.text)
+		}
+		return literalExpr{t: typeNumber, value: n}, p.next()
+
+	case wtOpen:
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		x, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok.kind != wtClose {
+			return nil, p.errorf(p.tok.pos, "expected ')' to close '(' at column %d, found %s", tok.pos+1, p.tok)
+		}
+		return x, p.next()
+
+	case wtOpenBracket:
+		return p.list()
+
+	case wtIdent:
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		switch tok.text {
+		case "true", "false":
+			return literalExpr{t: typeBool, value: tok.text == "true"}, nil
+		case "null":
+			return literalExpr{t: typeNull, value: (*time.Time)(nil)}, nil
+		case "labels":
+			return p.label()
+		}
+		if _, ok := whereFields[tok.text]; !ok {
+			return nil, p.errorf(tok.pos, "unknown field %q (valid: %s)", tok.text, strings.Join(WhereFields(), ", "))
+		}
+		return fieldExpr{name: tok.text}, nil
+	}
+	return nil, p.errorf(tok.pos, "expected a field, string, number or '(', found %s", tok)
+}
+
+// label parses the optional .key or ["key"] after labels.
+func (p *whereParser) label() (whereExpr, error) {
+	switch p.tok.kind {
+	case wtDot:
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind != wtIdent {
+			return nil, p.errorf(p.tok.pos, "expected a label key after 'labels.', found %s", p.tok)
+		}
+		key := p.tok.text
+		return labelExpr{key: key}, p.next()
+
+	case wtOpenBracket:
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind != wtString {
+			return nil, p.errorf(p.tok.pos, "expected a quoted label key, found %s", p.tok)
+		}
+		key := p.tok.value
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind != wtCloseBracket {
+			return nil, p.errorf(p.tok.pos, "expected ']', found %s", p.tok)
+		}
+		return labelExpr{key: key}, p.next()
+	}
+	return fieldExpr{name: "labels"}, nil
+}
+
+// list parses a list of string literals.
+func (p *whereParser) list() (whereExpr, error) {
+	open := p.tok
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	items := []string{}
+	for p.tok.kind != wtCloseBracket {
+		if p.tok.kind != wtString {
+			return nil, p.errorf(p.tok.pos, "lists may only hold strings, found %s", p.tok)
+		}
+		items = append(items, p.tok.value)
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		switch p.tok.kind {
+		case wtComma:
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+		case wtCloseBracket:
+		default:
+			return nil, p.errorf(p.tok.pos, "expected ',' or ']' to close '[' at column %d, found %s", open.pos+1, p.tok)
+		}
+	}
+	return listExpr{items: items}, p.next()
+}
+
+type whereTokenKind int
+
+const (
+	wtEOF whereTokenKind = iota
+	wtIdent
+	wtString
+	wtNumber
+	wtCompare
+	wtIn
+	wtAnd
+	wtOr
+	wtNot
+	wtOpen
+	wtClose
+	wtOpenBracket
+	wtCloseBracket
+	wtComma
+	wtDot
+)
+
+type whereToken struct {
+	kind  whereTokenKind
+	text  string // Source text
+	value string // Unquoted value of a string
+	pos   int
+}
+
+func (t whereToken) String() string {
+	if t.kind == wtEOF {
+		return "end of filter"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+type whereLexer struct {
+	input string
+	pos   int
+}
+
+func (l *whereLexer) next() (whereToken, error) {
+	for l.pos < len(l.input) && unicode.IsSpace(rune(l.input[l.pos])) {
+		l.pos++
+	}
+	start := l.pos
+	if start >= len(l.input) {
+		return whereToken{kind: wtEOF, pos: start}, nil
+	}
+
+	emit := func(kind whereTokenKind, n int) (whereToken, error) {
+		l.pos += n
+		return whereToken{kind: kind, text: l.input[start:l.pos], pos: start}, nil
+	}
+
+	rest := l.input[start:]
+	for _, op := range []string{"==", "!=", "<=", ">="} {
+		if strings.HasPrefix(rest, op) {
+			return emit(wtCompare, 2)
+		}
+	}
+	switch {
+	case strings.HasPrefix(rest, "&&"):
+		return emit(wtAnd, 2)
+	case strings.HasPrefix(rest, "||"):
+		return emit(wtOr, 2)
+	}
+	switch c := rest[0]; {
+	case c == '<' || c == '>':
+		return emit(wtCompare, 1)
+	case c == '!':
+		return emit(wtNot, 1)
+	case c == '(':
+		return emit(wtOpen, 1)
+	case c == ')':
+		return emit(wtClose, 1)
+	case c == '[':
+		return emit(wtOpenBracket, 1)
+	case c == ']':
+		return emit(wtCloseBracket, 1)
+	case c == ',':
+		return emit(wtComma, 1)
+	case c == '.':
+		return emit(wtDot, 1)
+	case c == '"' || c == '\'':
+		return l.quoted(c)
+	case c == '-' || c >= '0' && c <= '9':
+		n := 1
+		for n < len(rest) && (rest[n] >= '0' && rest[n] <= '9' || rest[n] == '.') {
+			n++
+		}
+		return emit(wtNumber, n)
+	case c == '_' || unicode.IsLetter(rune(c)):
+		n := 1
+		for n < len(rest) && (rest[n] == '_' || rest[n] == '-' || unicode.IsLetter(rune(rest[n])) || unicode.IsDigit(rune(rest[n]))) {
+			n++
+		}
+		if rest[:n] == "in" {
+			return emit(wtIn, n)
+		}
+		return emit(wtIdent, n)
+	}
+	return whereToken{}, whereErrorf(start, "unexpected character %q", rest[0])
+}
+
+// quoted lexes a string in single or double quotes with backslash escapes.
+func (l *whereLexer) quoted(quote byte) (whereToken, error) {
+	start := l.pos
+	var value strings.Builder
+	for i := start + 1; i < len(l.input); i++ {
+		switch c := l.input[i]; c {
+		case '\\':
+			if i+1 < len(l.input) {
+				i++
+				value.WriteByte(l.input[i])
+			}
+		case quote:
+			l.pos = i + 1
+			return whereToken{kind: wtString, text: l.input[start:l.pos], value: value.String(), pos: start}, nil
+		default:
+			value.WriteByte(c)
+		}
+	}
+	return whereToken{}, whereErrorf(start, "unterminated string")
+}
@@ -0,0 +1,124 @@
+package resource
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWhereMatches(t *testing.T) {
+	now := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	res := Resource{
+		ID:        "res-1",
+		Namespace: DefaultNamespace,
+		Version:   3,
+		Name:      "web",
+		Status:    StatusActive,
+		Tags:      []Tag{"prod", "web"},
+		Labels:    Labels{"tier": "frontend", "team/owner": "ops"},
+		CreatedAt: CreatedAt(time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)),
+		UpdatedAt: UpdatedAt(time.Date(2025, 6, 14, 0, 0, 0, 0, time.UTC)),
+	}
+
+	tests := []struct {
+		where Where
+		want  bool
+	}{
+		{``, true},
+		{`status == "active"`, true},
+		{`status != "active"`, false},
+		{`name == 'web'`, true},
+		{`name != "w\"eb"`, true},
+		{`version >= 3`, true},
+		{`version > 3`, false},
+		{`version == 3.0`, true},
+
+		// Membership
+		{`"prod" in tags`, true},
+		{`"dev" in tags`, false},
+		{`"tier" in labels`, true},
+		{`"zone" in labels`, false},
+		{`status in ["active", "pending"]`, true},
+		{`status in []`, false},
+
+		// Labels
+		{`labels.tier == "frontend"`, true},
+		{`labels["team/owner"] == "ops"`, true},
+		{`labels.zone == ""`, true},
+
+		// && binds tighter than ||; ! negates one comparison
+		{`status == "inactive" && name == "web" || name == "web"`, true},
+		{`name == "web" || name == "db" && status == "inactive"`, true},
+		{`(name == "web" || name == "db") && status == "inactive"`, false},
+		{`!(status == "active")`, false},
+		{`!status == "inactive" && name == "db"`, false},
+		{`!status == "active" || name == "web"`, true},
+		{`!!("prod" in tags)`, true},
+
+		// Strings compared with times are time specs resolved against now
+		{`created_at > "2025-06-01"`, true},
+		{`created_at > "7d"`, true},
+		{`created_at > "3d"`, false},
+		{`"2025-06-14T00:00:00Z" <= updated_at`, true},
+
+		// Unset times are null and not ordered
+		{`expires_at == null`, true},
+		{`deleted_at != null`, false},
+		{`expires_at < "2030-01-01"`, false},
+		{`expires_at != "2030-01-01"`, true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.where), func(t *testing.T) {
+			f, err := tt.where.Parse(now)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if got := f.Matches(res); got != tt.want {
+				t.Errorf("Matches = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWhereParseErrors(t *testing.T) {
+	tests := []struct {
+		where  Where
+		column int
+		want   string
+	}{
+		{`status`, 1, "filter must be a condition"},
+		{`status ==`, 10, "expected a field"},
+		{`name == "web" &&`, 17, "expected a field"},
+		{`name == "web" name`, 15, "expected an operator or end of filter"},
+		{`bogus == "x"`, 1, `unknown field "bogus"`},
+		{`status == 1`, 8, "cannot compare string status with number 1"},
+		{`tags == "x"`, 6, "cannot compare list tags with string"},
+		{`tags == tags`, 6, "cannot compare list values; use 'in'"},
+		{`expires_at < null`, 12, `"<" cannot order time values`},
+		{`"x" in status`, 8, "right of 'in' must be tags, labels or a list"},
+		{`version in tags`, 1, "left of 'in' must be a string"},
+		{`status in ["a", 1]`, 17, "lists may only hold strings"},
+		{`!name`, 2, "'!' needs a condition"},
+		{`name && status == "active"`, 1, "left of \"&&\" must be a condition"},
+		{`created_at > "soon"`, 14, `"soon" is not an RFC 3339 time`},
+		{`(name == "web"`, 15, "expected ')' to close '(' at column 1"},
+		{`labels. == "x"`, 9, "expected a label key"},
+		{`name == "web`, 9, "unterminated string"},
+		{`name == "web" # x`, 15, "unexpected character '#'"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.where), func(t *testing.T) {
+			_, err := tt.where.Parse(time.Now())
+			if err == nil {
+				t.Fatal("Parse succeeded, want an error")
+			}
+			if column := fmt.Sprintf("column %d:", tt.column); !strings.Contains(err.Error(), column) {
+				t.Errorf("error %q is not at %s", err, column)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error %q does not mention %q", err, tt.want)
+			}
+		})
+	}
+}